@@ -0,0 +1,92 @@
+// Command server runs the taxi-meter-go fleet backend: REST + WebSocket
+// endpoints over the same RideSession/TaxiMeter domain model the
+// single-process demo in the repository root uses.
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/szks-repo/taxi-meter-go/meter"
+	"github.com/szks-repo/taxi-meter-go/payment"
+	"github.com/szks-repo/taxi-meter-go/rules"
+	"github.com/szks-repo/taxi-meter-go/server"
+)
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	// テナントごとの料金設定は rules.RuleSetRegistry からロードする。
+	// 実運用ではテナントIDとリージョンの対応をDB/設定ストアから読み込む。
+	registry := rules.NewRegistry()
+	tenantRegions := map[string]string{
+		"tokyo": "tokyo",
+		"nyc":   "nyc",
+	}
+	tenants := make(map[string]meter.FareConfig, len(tenantRegions))
+	for tenantID, region := range tenantRegions {
+		cfg, err := registry.LoadRuleSet(region)
+		if err != nil {
+			logger.Error("failed to load ruleset", "tenant", tenantID, "region", region, "error", err)
+			os.Exit(1)
+		}
+		tenants[tenantID] = cfg
+	}
+
+	tokens := map[string]string{
+		"demo-token":     "tokyo",
+		"demo-token-nyc": "nyc",
+	}
+
+	// デモ用の決済プロバイダ構成: 実運用では秘密鍵をシークレットストアから読み込む
+	providers := map[meter.PaymentMethod]meter.PaymentProvider{
+		meter.PaymentMethodCash:    payment.NewCashProvider(),
+		meter.PaymentMethodCard:    payment.NewStripeProvider(os.Getenv("STRIPE_SECRET_KEY")),
+		meter.PaymentMethodDigital: payment.NewDigitalWalletProvider(payment.WalletKindLinePay, os.Getenv("LINE_PAY_BASE_URL"), os.Getenv("LINE_PAY_CHANNEL_ID"), os.Getenv("LINE_PAY_API_KEY")),
+	}
+	webhookSecret := os.Getenv("PAYMENT_WEBHOOK_SECRET")
+
+	store, err := newEventStore(logger)
+	if err != nil {
+		logger.Error("failed to set up event store", "error", err)
+		os.Exit(1)
+	}
+
+	srv := server.NewServer(tenants, tokens, providers, webhookSecret, store, logger)
+
+	addr := ":8080"
+	logger.Info("starting taxi-meter-go fleet backend", "addr", addr)
+	if err := http.ListenAndServe(addr, srv.Handler()); err != nil {
+		logger.Error("server stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+// newEventStore は DATABASE_DRIVER/DATABASE_DSN が両方設定されている場合に限り
+// meter.SQLEventStore をジャーナルとして使う (ドライバ自体は運用側のビルドで
+// blank importして登録する想定、EventStore 自体はドライバを import しないが、クエリは
+// `?` プレースホルダを使うため `?` を受け付けるドライバでのみ動作する)。
+// どちらか一方でも未設定なら、プロセス再起動をまたいだクラッシュ復旧はできない
+// meter.NewMemoryEventStore にフォールバックし、その旨を警告ログに残す。
+func newEventStore(logger *slog.Logger) (meter.EventStore, error) {
+	driver := os.Getenv("DATABASE_DRIVER")
+	dsn := os.Getenv("DATABASE_DSN")
+	if driver == "" || dsn == "" {
+		logger.Warn("DATABASE_DRIVER/DATABASE_DSN not set: using an in-memory event journal that will not survive a process restart")
+		return meter.NewMemoryEventStore(), nil
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open database (driver %q): %w", driver, err)
+	}
+
+	store := meter.NewSQLEventStore(db)
+	if err := store.EnsureSchema(); err != nil {
+		return nil, fmt.Errorf("ensure event store schema: %w", err)
+	}
+	return store, nil
+}
@@ -0,0 +1,146 @@
+package gps
+
+import (
+	"time"
+
+	"github.com/szks-repo/taxi-meter-go/meter"
+)
+
+// GPSFix は1回分の生GPSフィックスを表す
+type GPSFix struct {
+	Lat       float64
+	Lon       float64
+	Timestamp time.Time
+	Accuracy  float64 // 水平精度 (m)
+}
+
+// FilterConfig は GPSFeeder のフィルタ・ヒステリシスパラメータを表す
+type FilterConfig struct {
+	MaxAccuracyMeters     float64       // この値(m)を超える水平精度のフィックスは破棄
+	SpeedSmoothing        float64       // 速度の平滑化係数 (0<alpha<=1、小さいほどジッタに強い)
+	StopSpeedThresholdKmh float64       // この速度を下回る状態が続くと「停止」とみなす
+	StopSustainDuration   time.Duration // 停止とみなすまでの継続時間
+	MoveSpeedThresholdKmh float64       // この速度を上回る状態が続くと「走行」とみなす
+	MoveSustainDuration   time.Duration // 走行再開とみなすまでの継続時間
+}
+
+// DefaultFilterConfig は一般的な市街地走行を想定した既定値を返す
+func DefaultFilterConfig() FilterConfig {
+	return FilterConfig{
+		MaxAccuracyMeters:     30,
+		SpeedSmoothing:        0.3,
+		StopSpeedThresholdKmh: 3.0,
+		StopSustainDuration:   15 * time.Second,
+		MoveSpeedThresholdKmh: 5.0,
+		MoveSustainDuration:   10 * time.Second,
+	}
+}
+
+type movementState int
+
+const (
+	movementUnknown movementState = iota
+	movementMoving
+	movementStopped
+)
+
+// GPSFeeder は生のGPSフィックス列を meter.TripEvent 列に変換する
+type GPSFeeder struct {
+	cfg FilterConfig
+
+	lastFix          *GPSFix
+	smoothedSpeedKmh float64
+
+	state          movementState
+	candidateState movementState
+	candidateSince time.Time
+}
+
+// NewGPSFeeder は cfg のフィルタ設定を適用する GPSFeeder を作る
+func NewGPSFeeder(cfg FilterConfig) *GPSFeeder {
+	return &GPSFeeder{
+		cfg:   cfg,
+		state: movementStopped, // 乗車開始直後は停止扱いから始める
+	}
+}
+
+// Feed は1つのフィックスを取り込み、直前のフィックスとの区間から TripEvent を合成する。
+// 精度不足のフィックスや逆行タイムスタンプは破棄され、空スライスが返る。
+// 最初のフィックスは区間の起点としてのみ記録され、イベントは生成されない。
+func (f *GPSFeeder) Feed(fix GPSFix) []meter.TripEvent {
+	if fix.Accuracy > f.cfg.MaxAccuracyMeters {
+		return nil
+	}
+
+	if f.lastFix == nil {
+		f.lastFix = &fix
+		return nil
+	}
+
+	prev := *f.lastFix
+
+	duration := fix.Timestamp.Sub(prev.Timestamp)
+	if duration <= 0 {
+		return nil
+	}
+	f.lastFix = &fix
+
+	distanceKm := haversineDistanceKm(prev.Lat, prev.Lon, fix.Lat, fix.Lon)
+	rawSpeedKmh := distanceKm / duration.Hours()
+
+	// Kalman-liteなローパスフィルタ: 生の速度を指数移動平均でなだらかにする
+	f.smoothedSpeedKmh = f.cfg.SpeedSmoothing*rawSpeedKmh + (1-f.cfg.SpeedSmoothing)*f.smoothedSpeedKmh
+
+	f.updateMovementState(fix.Timestamp)
+
+	var eventType meter.TripEventType = meter.TripEventTypeMove
+	if f.state == movementStopped {
+		eventType = meter.TripEventTypeStop
+	}
+
+	return []meter.TripEvent{
+		{
+			EventType: eventType,
+			Timestamp: fix.Timestamp,
+			Distance:  distanceKm,
+			Duration:  duration,
+			Speed:     f.smoothedSpeedKmh,
+			Location:  &meter.LatLon{Lat: fix.Lat, Lon: fix.Lon},
+		},
+	}
+}
+
+// updateMovementState はヒステリシス付きで走行/停止状態を更新する。
+// 閾値をまたいだ瞬間に遷移させず、一定時間その速度域が継続して初めて状態を切り替えることで
+// 距離制/時間制のフラッピングを防ぐ。
+func (f *GPSFeeder) updateMovementState(now time.Time) {
+	switch f.state {
+	case movementMoving:
+		if f.smoothedSpeedKmh < f.cfg.StopSpeedThresholdKmh {
+			if f.candidateState != movementStopped {
+				f.candidateState = movementStopped
+				f.candidateSince = now
+			}
+			if now.Sub(f.candidateSince) >= f.cfg.StopSustainDuration {
+				f.state = movementStopped
+				f.candidateState = movementUnknown
+			}
+		} else {
+			f.candidateState = movementUnknown
+		}
+
+	default: // movementStopped, movementUnknown
+		if f.smoothedSpeedKmh > f.cfg.MoveSpeedThresholdKmh {
+			if f.candidateState != movementMoving {
+				f.candidateState = movementMoving
+				f.candidateSince = now
+			}
+			if now.Sub(f.candidateSince) >= f.cfg.MoveSustainDuration {
+				f.state = movementMoving
+				f.candidateState = movementUnknown
+			}
+		} else {
+			f.candidateState = movementUnknown
+		}
+	}
+}
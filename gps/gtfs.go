@@ -0,0 +1,32 @@
+package gps
+
+import (
+	"time"
+
+	"github.com/szks-repo/taxi-meter-go/meter"
+)
+
+// VehiclePosition は GTFS-realtime (transit_realtime.VehiclePosition) のうち
+// メータ入力の合成に必要なフィールドだけを取り出した最小表現。
+// 生成済みの .proto 型をそのまま使える環境では、このアダプタの入力をその型に差し替えればよい。
+type VehiclePosition struct {
+	Latitude  float32
+	Longitude float32
+	Timestamp uint64 // UNIX epoch秒
+	Accuracy  float64
+}
+
+// FixFromVehiclePosition は GTFS-realtime の VehiclePosition を GPSFix に変換する
+func FixFromVehiclePosition(vp VehiclePosition) GPSFix {
+	return GPSFix{
+		Lat:       float64(vp.Latitude),
+		Lon:       float64(vp.Longitude),
+		Timestamp: time.Unix(int64(vp.Timestamp), 0),
+		Accuracy:  vp.Accuracy,
+	}
+}
+
+// FeedVehiclePosition はフリートの GTFS-realtime フィードを直接 GPSFeeder に供給するアダプタ
+func (f *GPSFeeder) FeedVehiclePosition(vp VehiclePosition) []meter.TripEvent {
+	return f.Feed(FixFromVehiclePosition(vp))
+}
@@ -0,0 +1,8 @@
+package gps
+
+import "github.com/szks-repo/taxi-meter-go/meter"
+
+// haversineDistanceKm は2点間の大圏距離を km 単位で返す
+func haversineDistanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	return meter.DistanceKm(meter.LatLon{Lat: lat1, Lon: lon1}, meter.LatLon{Lat: lat2, Lon: lon2})
+}
@@ -0,0 +1,126 @@
+package meter
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+)
+
+// EventStore は RideSession のイベントジャーナルの永続化先を抽象化する
+type EventStore interface {
+	// Append はセッションのジャーナルにイベントを追記する
+	Append(sessionID string, event TripEvent) error
+	// Load はセッションのジャーナルを記録順に読み出す
+	Load(sessionID string) ([]TripEvent, error)
+}
+
+// MemoryEventStore はプロセス内メモリ上にジャーナルを保持する EventStore 実装
+type MemoryEventStore struct {
+	mu     sync.Mutex
+	events map[string][]TripEvent
+}
+
+func NewMemoryEventStore() *MemoryEventStore {
+	return &MemoryEventStore{events: make(map[string][]TripEvent)}
+}
+
+func (s *MemoryEventStore) Append(sessionID string, event TripEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events[sessionID] = append(s.events[sessionID], event)
+	return nil
+}
+
+func (s *MemoryEventStore) Load(sessionID string) ([]TripEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events := s.events[sessionID]
+	out := make([]TripEvent, len(events))
+	copy(out, events)
+	return out, nil
+}
+
+// SQLEventStoreSchema は SQLEventStore が読み書きするテーブルのDDL。
+// SQLEventStore のクエリは `?` プレースホルダを使うため、`?` を受け付けるドライバ (SQLite 等) で
+// 動作し、`$1` 形式のプレースホルダを要求するドライバ (PostgreSQL 等) では動作しない。
+const SQLEventStoreSchema = `
+CREATE TABLE IF NOT EXISTS trip_events (
+	session_id  TEXT NOT NULL,
+	seq         INTEGER NOT NULL,
+	event_type  INTEGER NOT NULL,
+	timestamp   TIMESTAMP NOT NULL,
+	distance_km DOUBLE PRECISION NOT NULL,
+	duration_ns BIGINT NOT NULL,
+	speed_kmh   DOUBLE PRECISION NOT NULL,
+	lat         DOUBLE PRECISION,
+	lon         DOUBLE PRECISION,
+	passenger_count INTEGER NOT NULL DEFAULT 0,
+	toll        INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (session_id, seq)
+)`
+
+// SQLEventStore は database/sql 経由でジャーナルを永続化する EventStore 実装
+type SQLEventStore struct {
+	db *sql.DB
+}
+
+func NewSQLEventStore(db *sql.DB) *SQLEventStore {
+	return &SQLEventStore{db: db}
+}
+
+// EnsureSchema は trip_events テーブルが存在しなければ作成する
+func (s *SQLEventStore) EnsureSchema() error {
+	_, err := s.db.Exec(SQLEventStoreSchema)
+	return err
+}
+
+func (s *SQLEventStore) Append(sessionID string, event TripEvent) error {
+	var lat, lon sql.NullFloat64
+	if event.Location != nil {
+		lat = sql.NullFloat64{Float64: event.Location.Lat, Valid: true}
+		lon = sql.NullFloat64{Float64: event.Location.Lon, Valid: true}
+	}
+
+	var seq int
+	row := s.db.QueryRow(`SELECT COALESCE(MAX(seq), 0) + 1 FROM trip_events WHERE session_id = ?`, sessionID)
+	if err := row.Scan(&seq); err != nil {
+		return err
+	}
+
+	_, err := s.db.Exec(
+		`INSERT INTO trip_events (session_id, seq, event_type, timestamp, distance_km, duration_ns, speed_kmh, lat, lon, passenger_count, toll)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		sessionID, seq, int(event.EventType), event.Timestamp, event.Distance, int64(event.Duration), event.Speed, lat, lon, event.PassengerCount, event.Toll,
+	)
+	return err
+}
+
+func (s *SQLEventStore) Load(sessionID string) ([]TripEvent, error) {
+	rows, err := s.db.Query(
+		`SELECT event_type, timestamp, distance_km, duration_ns, speed_kmh, lat, lon, passenger_count, toll
+		 FROM trip_events WHERE session_id = ? ORDER BY seq ASC`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []TripEvent
+	for rows.Next() {
+		var (
+			eventType  int
+			durationNs int64
+			lat, lon   sql.NullFloat64
+			event      TripEvent
+		)
+		if err := rows.Scan(&eventType, &event.Timestamp, &event.Distance, &durationNs, &event.Speed, &lat, &lon, &event.PassengerCount, &event.Toll); err != nil {
+			return nil, err
+		}
+		event.EventType = TripEventType(eventType)
+		event.Duration = time.Duration(durationNs)
+		if lat.Valid && lon.Valid {
+			event.Location = &LatLon{Lat: lat.Float64, Lon: lon.Float64}
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
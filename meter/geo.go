@@ -0,0 +1,46 @@
+package meter
+
+import "math"
+
+const earthRadiusKm = 6371.0088
+
+// LatLon は緯度経度の組を表す
+type LatLon struct {
+	Lat float64
+	Lon float64
+}
+
+// Polygon はゾーン境界を表す頂点列 (始点と終点を結んで閉路とみなす)
+type Polygon []LatLon
+
+// Contains は pt がポリゴン内部にあるかをレイキャスティング法で判定する
+func (p Polygon) Contains(pt LatLon) bool {
+	inside := false
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		vi, vj := p[i], p[j]
+		intersects := (vi.Lon > pt.Lon) != (vj.Lon > pt.Lon) &&
+			pt.Lat < (vj.Lat-vi.Lat)*(pt.Lon-vi.Lon)/(vj.Lon-vi.Lon)+vi.Lat
+		if intersects {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// DistanceKm は2点間の大圏距離を km 単位で返す
+func DistanceKm(a, b LatLon) float64 {
+	rLat1 := toRadians(a.Lat)
+	rLat2 := toRadians(b.Lat)
+	dLat := toRadians(b.Lat - a.Lat)
+	dLon := toRadians(b.Lon - a.Lon)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rLat1)*math.Cos(rLat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	c := 2 * math.Atan2(math.Sqrt(h), math.Sqrt(1-h))
+
+	return earthRadiusKm * c
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
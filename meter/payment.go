@@ -0,0 +1,222 @@
+package meter
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ProcessPayment は provider を通じて運賃の与信・確定を行う。
+// idempotencyKey が直前の決済と同じ場合は二重課金せず、前回の成功結果をそのまま返す
+// (クライアントのリトライに対して安全)。
+func (rs *RideSession) ProcessPayment(ctx context.Context, provider PaymentProvider, idempotencyKey string, now time.Time) EventResult {
+	var result EventResult
+	result.LogMessages = make([]string, 0)
+
+	if rs.Status != StatusCompleted {
+		result.Success = false
+		result.Error = fmt.Errorf("cannot process payment for incomplete ride")
+		result.Message = "決済処理に失敗：乗車が完了していません"
+		return result
+	}
+
+	if rs.PaymentInfo != nil {
+		if rs.PaymentInfo.IdempotencyKey == idempotencyKey {
+			if rs.PaymentInfo.Status == PaymentStatusFailed {
+				result.Success = false
+				result.Error = fmt.Errorf("previous payment attempt with this idempotency key failed")
+				result.Message = "決済処理に失敗：前回の決済が失敗しています"
+				return result
+			}
+			result.Success = true
+			result.Message = "決済完了 (リトライ、冪等キーにより二重課金を回避)"
+			result.NewTotalFare = rs.PaymentInfo.Amount
+			result.LogMessages = append(result.LogMessages, fmt.Sprintf("💳 決済リトライを検知: %s", idempotencyKey))
+			return result
+		}
+		result.Success = false
+		result.Error = fmt.Errorf("payment already processed")
+		result.Message = "決済処理に失敗：既に決済済みです"
+		return result
+	}
+
+	amount := rs.Meter.GetCurrentFare()
+
+	authResult, err := provider.Authorize(ctx, AuthorizeRequest{
+		IdempotencyKey: idempotencyKey,
+		SessionID:      rs.SessionID,
+		Payer:          rs.Passenger,
+		Amount:         amount,
+		Currency:       rs.currency(),
+	})
+	if err != nil {
+		result.Success = false
+		result.Error = fmt.Errorf("authorize: %w", err)
+		result.Message = "決済処理に失敗：与信に失敗しました"
+		return result
+	}
+
+	info := &PaymentInfo{
+		Method:         provider.Method(),
+		Amount:         amount,
+		Provider:       provider.Name(),
+		ProviderRef:    authResult.ProviderRef,
+		IdempotencyKey: idempotencyKey,
+	}
+	info.recordTransition(authResult.Status, now)
+	rs.PaymentInfo = info
+
+	captureResult, err := provider.Capture(ctx, CaptureRequest{
+		IdempotencyKey: idempotencyKey,
+		ProviderRef:    authResult.ProviderRef,
+		Amount:         amount,
+	})
+	if err != nil {
+		// 確定に失敗した場合は与信を取り消して後に残高が拘束されたままにしない
+		_, _ = provider.Void(ctx, VoidRequest{ProviderRef: authResult.ProviderRef})
+		info.recordTransition(PaymentStatusFailed, now)
+		result.Success = false
+		result.Error = fmt.Errorf("capture: %w", err)
+		result.Message = "決済処理に失敗：確定処理に失敗しました"
+		return result
+	}
+
+	info.recordTransition(captureResult.Status, now)
+	info.ProcessedAt = &now
+
+	result.Success = true
+	result.Message = "決済完了"
+	result.NewTotalFare = info.Amount
+	result.LogMessages = append(result.LogMessages, fmt.Sprintf("💳 決済完了: %s (%s) - %d円", info.Method, provider.Name(), info.Amount))
+
+	return result
+}
+
+// ConfirmCapture は決済プロバイダからの非同期確定通知 (webhook) を反映する。
+// 進行中の決済と ProviderRef が一致しない場合はエラーを返す。
+func (rs *RideSession) ConfirmCapture(providerRef string, status PaymentStatus, at time.Time) error {
+	if rs.PaymentInfo == nil {
+		return fmt.Errorf("confirm capture: no payment in progress")
+	}
+	if rs.PaymentInfo.ProviderRef != providerRef {
+		return fmt.Errorf("confirm capture: provider ref mismatch")
+	}
+
+	rs.PaymentInfo.recordTransition(status, at)
+	if status == PaymentStatusCaptured {
+		rs.PaymentInfo.ProcessedAt = &at
+	}
+	return nil
+}
+
+// SplitFareResult は SplitFare の結果を表す
+type SplitFareResult struct {
+	Payments []PaymentInfo
+}
+
+// SplitFare は運賃を shares の比率で participants に配分し、対応する providers に対して
+// それぞれ個別に与信・確定を行う。1人でも確定に失敗した場合は、既に確定済みの分も含めて
+// 全員分を取り消し (void) し、乗車の決済全体を失敗として扱う。
+func (rs *RideSession) SplitFare(ctx context.Context, participants []Passenger, providers []PaymentProvider, shares []int, now time.Time) (SplitFareResult, error) {
+	if len(participants) != len(providers) || len(participants) != len(shares) {
+		return SplitFareResult{}, fmt.Errorf("splitfare: participants, providers and shares must have equal length")
+	}
+	if rs.Status != StatusCompleted {
+		return SplitFareResult{}, fmt.Errorf("splitfare: cannot split fare for incomplete ride")
+	}
+
+	totalShares := 0
+	for _, share := range shares {
+		totalShares += share
+	}
+	if totalShares <= 0 {
+		return SplitFareResult{}, fmt.Errorf("splitfare: shares must sum to a positive number")
+	}
+
+	totalFare := rs.Meter.GetCurrentFare()
+	amounts := splitAmounts(totalFare, shares, totalShares)
+
+	payments := make([]PaymentInfo, len(participants))
+	captured := make([]capturedAuthorization, 0, len(participants))
+
+	for i := range participants {
+		amount := amounts[i]
+		idempotencyKey := fmt.Sprintf("%s-split-%d", rs.SessionID, i)
+
+		authResult, err := providers[i].Authorize(ctx, AuthorizeRequest{
+			IdempotencyKey: idempotencyKey,
+			SessionID:      rs.SessionID,
+			Payer:          participants[i],
+			Amount:         amount,
+			Currency:       rs.currency(),
+		})
+		if err != nil {
+			rs.voidAll(ctx, captured)
+			return SplitFareResult{}, fmt.Errorf("splitfare: authorize participant %d: %w", i, err)
+		}
+
+		captureResult, err := providers[i].Capture(ctx, CaptureRequest{
+			IdempotencyKey: idempotencyKey,
+			ProviderRef:    authResult.ProviderRef,
+			Amount:         amount,
+		})
+		if err != nil {
+			_, _ = providers[i].Void(ctx, VoidRequest{ProviderRef: authResult.ProviderRef})
+			rs.voidAll(ctx, captured)
+			return SplitFareResult{}, fmt.Errorf("splitfare: capture participant %d: %w", i, err)
+		}
+
+		info := PaymentInfo{
+			Method:         providers[i].Method(),
+			Amount:         amount,
+			Provider:       providers[i].Name(),
+			ProviderRef:    authResult.ProviderRef,
+			IdempotencyKey: idempotencyKey,
+			ProcessedAt:    &now,
+		}
+		info.recordTransition(authResult.Status, now)
+		info.recordTransition(captureResult.Status, now)
+		payments[i] = info
+
+		captured = append(captured, capturedAuthorization{provider: providers[i], ref: authResult.ProviderRef})
+	}
+
+	rs.SplitPayments = payments
+
+	return SplitFareResult{Payments: payments}, nil
+}
+
+// splitAmounts は totalFare を shares の比率で切り捨て配分し、端数は最後の参加者に
+// 寄せることで合計が必ず totalFare に一致するようにする。
+func splitAmounts(totalFare int, shares []int, totalShares int) []int {
+	amounts := make([]int, len(shares))
+	allocated := 0
+	for i, share := range shares {
+		amounts[i] = totalFare * share / totalShares
+		allocated += amounts[i]
+	}
+	amounts[len(amounts)-1] += totalFare - allocated
+	return amounts
+}
+
+// capturedAuthorization は SplitFare が途中で失敗した際に取り消す対象を記録する
+type capturedAuthorization struct {
+	provider PaymentProvider
+	ref      string
+}
+
+// voidAll はSplitFareの途中で一部が失敗した際に、既に確定済みの分を全て取り消す
+func (rs *RideSession) voidAll(ctx context.Context, captured []capturedAuthorization) {
+	for _, c := range captured {
+		_, _ = c.provider.Void(ctx, VoidRequest{ProviderRef: c.ref})
+	}
+}
+
+// currency は決済プロバイダに渡す通貨コードを返す。Config.Currencyが未設定の場合は
+// 後方互換として "JPY" を既定値とする。
+func (rs *RideSession) currency() string {
+	if rs.Meter.Config.Currency == "" {
+		return "JPY"
+	}
+	return rs.Meter.Config.Currency
+}
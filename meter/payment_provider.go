@@ -0,0 +1,67 @@
+package meter
+
+import "context"
+
+// PaymentProvider は決済ゲートウェイ (現金・カード・電子ウォレット等) を抽象化する。
+// Authorize/Capture/Void/Refund はクレジットカード決済の標準的なライフサイクルに倣う。
+type PaymentProvider interface {
+	// Name はログ・PaymentInfo.Provider に記録する識別名を返す
+	Name() string
+	// Method はこのプロバイダが扱う PaymentMethod を返す
+	Method() PaymentMethod
+
+	Authorize(ctx context.Context, req AuthorizeRequest) (AuthorizeResult, error)
+	Capture(ctx context.Context, req CaptureRequest) (CaptureResult, error)
+	Void(ctx context.Context, req VoidRequest) (VoidResult, error)
+	Refund(ctx context.Context, req GatewayRefundRequest) (GatewayRefundResult, error)
+}
+
+// AuthorizeRequest は与信 (仮売上) のリクエストを表す
+type AuthorizeRequest struct {
+	IdempotencyKey string
+	SessionID      string
+	Payer          Passenger
+	Amount         int
+	Currency       string // 例: "JPY"
+}
+
+// AuthorizeResult は与信の結果を表す
+type AuthorizeResult struct {
+	ProviderRef string // プロバイダ側の与信ID
+	Status      PaymentStatus
+}
+
+// CaptureRequest は確定売上のリクエストを表す
+type CaptureRequest struct {
+	IdempotencyKey string
+	ProviderRef    string
+	Amount         int
+}
+
+// CaptureResult は確定売上の結果を表す
+type CaptureResult struct {
+	ProviderRef string
+	Status      PaymentStatus
+}
+
+// VoidRequest は与信取消のリクエストを表す
+type VoidRequest struct {
+	ProviderRef string
+}
+
+// VoidResult は与信取消の結果を表す
+type VoidResult struct {
+	Status PaymentStatus
+}
+
+// GatewayRefundRequest は確定済み決済の返金リクエストを表す。
+// 乗車中の運賃払戻 (Refund/RefundResult) とは別物なので Gateway を冠している。
+type GatewayRefundRequest struct {
+	ProviderRef string
+	Amount      int
+}
+
+// GatewayRefundResult は決済ゲートウェイへの返金結果を表す
+type GatewayRefundResult struct {
+	Status PaymentStatus
+}
@@ -0,0 +1,109 @@
+package meter
+
+import (
+	"sync"
+	"time"
+)
+
+// PricingContext は PricingModifier を評価する時点の状況を表す
+type PricingContext struct {
+	Now      time.Time
+	Location *LatLon // 現在地 (不明な場合は nil)
+}
+
+// PricingModifier は運賃増分にかける倍率を決定する
+type PricingModifier interface {
+	// Name はログ・EventResult.LogMessages に表示する識別名を返す
+	Name() string
+	// Multiplier は ctx の時点で適用すべき倍率と、このモディファイアが実際に発火したかを返す
+	Multiplier(ctx PricingContext) (factor float64, fired bool)
+}
+
+// TimeOfDayModifier は時間帯に応じたサーチャージ/割引を表す。
+// StartHour > EndHour の場合は日をまたぐ時間帯 (例: 22時〜翌5時) として扱う。
+type TimeOfDayModifier struct {
+	Label     string
+	StartHour int     // 0-23, 適用開始時刻 (この時刻を含む)
+	EndHour   int     // 0-23, 適用終了時刻 (この時刻を含まない)
+	Factor    float64 // 例: 1.2 で深夜+20%
+}
+
+// NewLateNightModifier は深夜22:00〜翌5:00に+20%のサーチャージをかけるモディファイアを返す
+func NewLateNightModifier() TimeOfDayModifier {
+	return TimeOfDayModifier{
+		Label:     "深夜割増",
+		StartHour: 22,
+		EndHour:   5,
+		Factor:    1.2,
+	}
+}
+
+func (m TimeOfDayModifier) Name() string {
+	return m.Label
+}
+
+func (m TimeOfDayModifier) Multiplier(ctx PricingContext) (float64, bool) {
+	h := ctx.Now.Hour()
+
+	var inWindow bool
+	if m.StartHour <= m.EndHour {
+		inWindow = h >= m.StartHour && h < m.EndHour
+	} else {
+		inWindow = h >= m.StartHour || h < m.EndHour
+	}
+
+	if !inWindow {
+		return 1.0, false
+	}
+	return m.Factor, true
+}
+
+// ZoneSurgeModifier はポリゴンで定義された需要過多エリア内での乗車にサーチャージをかける
+type ZoneSurgeModifier struct {
+	Label  string
+	Zone   Polygon
+	Factor float64
+}
+
+func (m ZoneSurgeModifier) Name() string {
+	return m.Label
+}
+
+func (m ZoneSurgeModifier) Multiplier(ctx PricingContext) (float64, bool) {
+	if ctx.Location == nil || !m.Zone.Contains(*ctx.Location) {
+		return 1.0, false
+	}
+	return m.Factor, true
+}
+
+// EventSurgeModifier はイベント等に応じて運用側が実行時にプッシュする倍率を表す。
+// 倍率1.0は「サージなし」を意味し、発火したとはみなさない。
+type EventSurgeModifier struct {
+	Label string
+
+	mu     sync.RWMutex
+	factor float64
+}
+
+// NewEventSurgeModifier はサージなし(倍率1.0)の状態で初期化された EventSurgeModifier を返す
+func NewEventSurgeModifier(label string) *EventSurgeModifier {
+	return &EventSurgeModifier{Label: label, factor: 1.0}
+}
+
+func (m *EventSurgeModifier) Name() string {
+	return m.Label
+}
+
+func (m *EventSurgeModifier) Multiplier(PricingContext) (float64, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.factor, m.factor != 1.0
+}
+
+// Push は現在の倍率を更新する。ディスパッチャやオペレーションダッシュボードから
+// 任意のタイミングで呼び出せる。
+func (m *EventSurgeModifier) Push(factor float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.factor = factor
+}
@@ -0,0 +1,118 @@
+package meter
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// RefundResult は Refund によって取り消された運賃増分と、払戻後の状態を表す
+type RefundResult struct {
+	ReversedIncrements []FareIncrement
+	CurrentFare        int
+	PaymentInfo        *PaymentInfo
+}
+
+// Refund は hangupTime 以降の区間に対応する運賃増分を、記録済みの FareIncrement を
+// 新しいものから順にたどって取り消す。区間が hangupTime をまたぐ場合は、時間制の増分は
+// 残り時間の割合で、距離制の増分は残り距離の割合で按分して一部のみ払い戻す
+// (cgratesのタイムスパン/インクリメント払戻と同様のモデル)。
+func (rs *RideSession) Refund(hangupTime time.Time) (RefundResult, error) {
+	if rs.Meter == nil {
+		return RefundResult{}, fmt.Errorf("refund: ride session has no meter")
+	}
+
+	var reversed []FareIncrement
+	newFare := rs.Meter.CurrentFare
+
+	for i := len(rs.Meter.Increments) - 1; i >= 0; i-- {
+		increment := rs.Meter.Increments[i]
+
+		if !increment.EndAt.After(hangupTime) {
+			// これより前の増分は全てhangupTime以前に完結しているため払戻対象外
+			break
+		}
+
+		if !increment.StartAt.Before(hangupTime) {
+			// 増分全体がhangupTime以降 -> 全額払戻
+			reversed = append(reversed, increment)
+			newFare -= increment.Amount
+			continue
+		}
+
+		// hangupTimeが増分の区間内にある -> 残りの割合だけ按分して払戻
+		if partial, ok := splitIncrementAfter(increment, hangupTime); ok {
+			reversed = append(reversed, partial)
+			newFare -= partial.Amount
+		}
+		break
+	}
+
+	rs.Meter.CurrentFare = newFare
+
+	if rs.PaymentInfo != nil {
+		adjusted := *rs.PaymentInfo
+		adjusted.Amount = newFare
+		rs.PaymentInfo = &adjusted
+	}
+
+	return RefundResult{
+		ReversedIncrements: reversed,
+		CurrentFare:        newFare,
+		PaymentInfo:        rs.PaymentInfo,
+	}, nil
+}
+
+// splitIncrementAfter は increment のうち hangupTime より後ろの部分だけを払戻対象として切り出す
+func splitIncrementAfter(increment FareIncrement, hangupTime time.Time) (FareIncrement, bool) {
+	total := increment.EndAt.Sub(increment.StartAt)
+	if total <= 0 {
+		// 初乗り料金のような瞬間的な増分は按分できない
+		return FareIncrement{}, false
+	}
+
+	remaining := increment.EndAt.Sub(hangupTime)
+	if remaining <= 0 {
+		return FareIncrement{}, false
+	}
+
+	ratio := float64(remaining) / float64(total)
+
+	partial := FareIncrement{
+		StartAt: hangupTime,
+		EndAt:   increment.EndAt,
+		Amount:  int(math.Round(float64(increment.Amount) * ratio)),
+		Reason:  fmt.Sprintf("%s の一部払戻 (按分率 %.2f)", increment.Reason, ratio),
+		Kind:    increment.Kind,
+	}
+	switch increment.Kind {
+	case FareIncrementKindDistance:
+		partial.Distance = increment.Distance * ratio
+	case FareIncrementKindTime:
+		partial.Duration = time.Duration(float64(increment.Duration) * ratio)
+	}
+
+	if partial.Amount == 0 {
+		return FareIncrement{}, false
+	}
+	return partial, true
+}
+
+// Replay は記録済みのイベント列からセッションとメータの状態を決定的に再構築する。
+// ジャーナルへの再書き込みは行わないため、クラッシュ後の復旧で EventStore から
+// 読み出したイベントをそのまま渡せる。
+func (rs *RideSession) Replay(events []TripEvent) error {
+	fresh := NewRideSession(rs.SessionID, rs.Driver, rs.Passenger, rs.Meter.Config)
+
+	for _, event := range events {
+		if result := fresh.applyEvent(event); !result.Success {
+			return fmt.Errorf("replay failed at event type %v: %w", event.EventType, result.Error)
+		}
+	}
+
+	store := rs.Store
+	*rs = *fresh
+	rs.Store = store
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package meter
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+type RideSession struct {
+	SessionID     string
+	Driver        Driver
+	Passenger     Passenger
+	StartTime     time.Time
+	EndTime       *time.Time
+	Status        SessionStatus
+	Meter         *TaxiMeter
+	Events        []TripEvent
+	PaymentInfo   *PaymentInfo
+	SplitPayments []PaymentInfo // SplitFare で各参加者ごとに確定した決済 (任意)
+	Store         EventStore    // イベントジャーナルの永続化先 (任意)
+}
+
+func NewRideSession(sessionID string, driver Driver, passenger Passenger, config FareConfig) *RideSession {
+	return &RideSession{
+		SessionID: sessionID,
+		Driver:    driver,
+		Passenger: passenger,
+		Status:    StatusWaiting,
+		Meter:     NewTaxiMeter(config),
+		Events:    make([]TripEvent, 0, 64),
+	}
+}
+
+// WithEventStore は rs にイベントジャーナルの永続化先を設定し、rs 自身を返す
+func (rs *RideSession) WithEventStore(store EventStore) *RideSession {
+	rs.Store = store
+	return rs
+}
+
+func (rs *RideSession) ProcessEvent(event TripEvent) EventResult {
+	result := rs.applyEvent(event)
+	if !result.Success || rs.Store == nil {
+		return result
+	}
+
+	if err := rs.Store.Append(rs.SessionID, event); err != nil {
+		result.Success = false
+		result.Error = fmt.Errorf("event store: %w", err)
+		result.Message = "イベント永続化に失敗"
+	}
+
+	return result
+}
+
+// applyEvent はセッション状態とメータを更新する。ジャーナルへの永続化は行わない
+// (Replay が記録済みのイベントを再適用する際に二重書き込みを避けるため)。
+func (rs *RideSession) applyEvent(event TripEvent) EventResult {
+	var result EventResult
+	result.LogMessages = make([]string, 0)
+
+	// イベントを記録
+	rs.Events = append(rs.Events, event)
+
+	// セッション状態を更新
+	switch event.EventType {
+	case TripEventTypeStart:
+		if rs.Status != StatusWaiting {
+			result.Success = false
+			result.Error = fmt.Errorf("cannot start ride in status: %s", rs.Status)
+			result.Message = "セッション開始に失敗"
+			return result
+		}
+		rs.Status = StatusOnboard
+		rs.StartTime = event.Timestamp
+		result.LogMessages = append(result.LogMessages, fmt.Sprintf("🚕 セッション開始 (ID: %s)", rs.SessionID))
+
+	case TripEventTypeEnd:
+		if rs.Status != StatusOnboard {
+			result.Success = false
+			result.Error = fmt.Errorf("cannot end ride in status: %s", rs.Status)
+			result.Message = "セッション終了に失敗"
+			return result
+		}
+		rs.Status = StatusCompleted
+		endTime := event.Timestamp
+		rs.EndTime = &endTime
+		result.LogMessages = append(result.LogMessages, fmt.Sprintf("🏁 セッション終了 (ID: %s)", rs.SessionID))
+	}
+
+	// メータを更新
+	meterResult := rs.Meter.ProcessEvent(event)
+
+	if meterResult.Error != nil {
+		result.Success = false
+		result.Error = meterResult.Error
+		result.Message = meterResult.Message
+		return result
+	}
+
+	// 結果をマージ
+	result.Success = true
+	result.Message = meterResult.Message
+	result.FareChange = meterResult.FareChange
+	result.NewTotalFare = rs.Meter.GetCurrentFare()
+	result.LogMessages = append(result.LogMessages, meterResult.LogMessages...)
+
+	return result
+}
+
+// PriceEstimate は乗車前の概算運賃を (min, max) の幅で返す。
+// min は時間帯・ゾーンのサージが一切発火しない場合の運賃、max は出発地・到着地それぞれの時点で
+// Config.Modifiers のうち1.0を超える倍率が発火した場合の最大運賃を表す。
+func (rs *RideSession) PriceEstimate(origin, dest LatLon, departAt time.Time) (min, max int) {
+	cfg := rs.Meter.Config
+	base := estimateBaseFare(cfg, DistanceKm(origin, dest))
+
+	min = base
+	max = base
+
+	for _, loc := range []LatLon{origin, dest} {
+		ctx := PricingContext{Now: departAt, Location: &loc}
+		candidate := base
+		for _, modifier := range cfg.Modifiers {
+			factor, fired := modifier.Multiplier(ctx)
+			if !fired || factor <= 1.0 {
+				continue
+			}
+			candidate = int(math.Round(float64(candidate) * factor))
+		}
+		if candidate > max {
+			max = candidate
+		}
+	}
+
+	return min, max
+}
+
+// estimateBaseFare は走行実績を伴わない事前見積もり用に、距離だけから運賃を概算する
+func estimateBaseFare(cfg FareConfig, distKm float64) int {
+	if len(cfg.DistanceTiers) > 0 {
+		return cfg.InitialFare + tieredFare(distKm, cfg.DistanceTiers)
+	}
+	if distKm <= cfg.InitialDistance {
+		return cfg.InitialFare
+	}
+	chargeable := distKm - cfg.InitialDistance
+	units := int(math.Ceil(chargeable / cfg.UnitDistance))
+	return cfg.InitialFare + units*cfg.UnitFare
+}
+
+func (rs *RideSession) GetSessionSummary() map[string]any {
+	summary := map[string]any{
+		"session_id":     rs.SessionID,
+		"driver":         rs.Driver.Name,
+		"passenger":      rs.Passenger.Name,
+		"status":         rs.Status,
+		"start_time":     rs.StartTime,
+		"end_time":       rs.EndTime,
+		"total_distance": rs.Meter.GetTotalDistance(),
+		"final_fare":     rs.Meter.GetCurrentFare(),
+		"event_count":    len(rs.Events),
+	}
+
+	if rs.PaymentInfo != nil {
+		summary["payment_method"] = rs.PaymentInfo.Method
+		summary["payment_processed"] = rs.PaymentInfo.ProcessedAt
+	}
+
+	return summary
+}
@@ -0,0 +1,450 @@
+package meter
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+type TaxiMeter struct {
+	Config        FareConfig
+	TotalDistance float64
+	TotalTime     time.Duration
+	CurrentFare   int
+	IsRunning     bool
+	StartTime     time.Time
+	LastEventTime time.Time
+	Increments    []FareIncrement // 課金済みの運賃増分の履歴 (払戻計算の元データ)
+
+	graceRemaining time.Duration // WaitingGracePeriodの残り猶予
+	originLocation *LatLon       // 乗車開始地点 (空港定額区間判定用)
+	lastLocation   *LatLon       // 直近の既知地点 (空港定額区間判定用)
+}
+
+func NewTaxiMeter(config FareConfig) *TaxiMeter {
+	return &TaxiMeter{
+		Config: config,
+	}
+}
+
+func (tm *TaxiMeter) ProcessEvent(event TripEvent) EventResult {
+	var result EventResult
+	result.LogMessages = make([]string, 0)
+	oldFare := tm.CurrentFare
+
+	switch event.EventType {
+	case TripEventTypeStart:
+		meterResult := tm.startTrip(event)
+		result = meterResult
+	case TripEventTypeMove:
+		meterResult := tm.processMovement(event)
+		result = meterResult
+	case TripEventTypeStop:
+		meterResult := tm.processStop(event)
+		result = meterResult
+	case TripEventTypeEnd:
+		meterResult := tm.endTrip(event)
+		result = meterResult
+	default:
+		result.Success = false
+		result.Error = fmt.Errorf("unknown event type: %v", event.EventType)
+		result.Message = "不明なイベントタイプ"
+		return result
+	}
+
+	// 料金変更額を計算
+	result.FareChange = tm.CurrentFare - oldFare
+	result.NewTotalFare = tm.CurrentFare
+
+	return result
+}
+
+func (tm *TaxiMeter) startTrip(event TripEvent) EventResult {
+	var result EventResult
+	result.LogMessages = make([]string, 0)
+
+	if tm.IsRunning {
+		result.Success = false
+		result.Error = fmt.Errorf("trip already started")
+		result.Message = "メータ開始に失敗：既に開始済み"
+		return result
+	}
+
+	tm.IsRunning = true
+	tm.StartTime = event.Timestamp
+	tm.LastEventTime = event.Timestamp
+	tm.CurrentFare = tm.Config.InitialFare
+	tm.TotalDistance = 0
+	tm.TotalTime = 0
+	tm.Increments = nil
+	tm.graceRemaining = tm.Config.WaitingGracePeriod
+	tm.originLocation = event.Location
+	tm.lastLocation = event.Location
+	if tm.CurrentFare > 0 {
+		tm.Increments = append(tm.Increments, FareIncrement{
+			StartAt: event.Timestamp,
+			EndAt:   event.Timestamp,
+			Amount:  tm.CurrentFare,
+			Reason:  "初乗り料金",
+			Kind:    FareIncrementKindInitial,
+		})
+	}
+
+	result.Success = true
+	result.Message = "メータ開始"
+	result.LogMessages = append(result.LogMessages, fmt.Sprintf("🚕 乗車開始 - 初乗り料金: %d円", tm.CurrentFare))
+
+	if passengers := event.PassengerCount; passengers > 1 && tm.Config.PerPassengerSurcharge > 0 {
+		extra := (passengers - 1) * tm.Config.PerPassengerSurcharge
+		tm.CurrentFare += extra
+		tm.Increments = append(tm.Increments, FareIncrement{
+			StartAt: event.Timestamp,
+			EndAt:   event.Timestamp,
+			Amount:  extra,
+			Reason:  "乗客追加料金",
+			Kind:    FareIncrementKindSurcharge,
+		})
+		result.LogMessages = append(result.LogMessages, fmt.Sprintf("🧍 乗客追加料金 (%d人) +%d円 (現在: %d円)", passengers, extra, tm.CurrentFare))
+	}
+
+	return result
+}
+
+func (tm *TaxiMeter) processMovement(event TripEvent) EventResult {
+	var result EventResult
+	result.LogMessages = make([]string, 0)
+
+	if !tm.IsRunning {
+		result.Success = false
+		result.Error = fmt.Errorf("trip not started")
+		result.Message = "移動処理に失敗：メータが開始されていません"
+		return result
+	}
+
+	oldFare := tm.CurrentFare
+	intervalStart := tm.LastEventTime
+	tm.TotalDistance += event.Distance
+	tm.TotalTime += event.Duration
+	tm.LastEventTime = event.Timestamp
+	if event.Location != nil {
+		tm.lastLocation = event.Location
+	}
+
+	if tm.Config.TollPassThrough && event.Toll > 0 {
+		tm.CurrentFare += event.Toll
+		tm.recordIncrement(intervalStart, event, FareCalculationInfo{Amount: event.Toll, Reason: "高速料金"}, FareIncrementKindToll)
+		result.LogMessages = append(result.LogMessages, fmt.Sprintf("🛣️  高速料金 +%d円 (現在: %d円)", event.Toll, tm.CurrentFare))
+	}
+
+	// 速度に基づいて料金計算方法を決定
+	if event.Speed <= tm.Config.TimeThreshold {
+		// 低速時は時間制
+		fareInfo := tm.calculateTimeFare(event)
+		tm.CurrentFare += fareInfo.Amount
+		tm.recordIncrement(intervalStart, event, fareInfo, FareIncrementKindTime)
+		result.LogMessages = append(result.LogMessages, fmt.Sprintf("⏱️  低速移動 (%.1f km/h) - 時間制料金加算", event.Speed))
+		if fareInfo.Amount > 0 {
+			result.LogMessages = append(result.LogMessages, fmt.Sprintf("   時間料金 +%d円 (現在: %d円)", fareInfo.Amount, tm.CurrentFare))
+		}
+		result.LogMessages = append(result.LogMessages, modifierLogMessages(fareInfo)...)
+	} else {
+		// 通常時は距離制
+		fareInfo := tm.calculateDistanceFare(event)
+		tm.CurrentFare += fareInfo.Amount
+		tm.recordIncrement(intervalStart, event, fareInfo, FareIncrementKindDistance)
+		result.LogMessages = append(result.LogMessages, fmt.Sprintf("🏃 通常移動 (%.1f km/h) - 距離制料金加算", event.Speed))
+		if fareInfo.Amount > 0 {
+			result.LogMessages = append(result.LogMessages, fmt.Sprintf("   距離料金 +%d円 (現在: %d円)", fareInfo.Amount, tm.CurrentFare))
+		}
+		result.LogMessages = append(result.LogMessages, modifierLogMessages(fareInfo)...)
+	}
+
+	result.Success = true
+	result.Message = "移動処理完了"
+	result.FareChange = tm.CurrentFare - oldFare
+
+	return result
+}
+
+func (tm *TaxiMeter) processStop(event TripEvent) EventResult {
+	var result EventResult
+	result.LogMessages = make([]string, 0)
+
+	if !tm.IsRunning {
+		result.Success = false
+		result.Error = fmt.Errorf("trip not started")
+		result.Message = "停止処理に失敗：メータが開始されていません"
+		return result
+	}
+
+	oldFare := tm.CurrentFare
+	intervalStart := tm.LastEventTime
+	tm.TotalTime += event.Duration
+	tm.LastEventTime = event.Timestamp
+	if event.Location != nil {
+		tm.lastLocation = event.Location
+	}
+
+	// 停止時間も時間制で加算。ただしWaitingGracePeriodの猶予分は課金対象から差し引く
+	chargeableEvent := event
+	if tm.graceRemaining > 0 {
+		if event.Duration <= tm.graceRemaining {
+			tm.graceRemaining -= event.Duration
+			chargeableEvent.Duration = 0
+		} else {
+			chargeableEvent.Duration -= tm.graceRemaining
+			tm.graceRemaining = 0
+		}
+	}
+	fareInfo := tm.calculateTimeFare(chargeableEvent)
+	tm.CurrentFare += fareInfo.Amount
+	tm.recordIncrement(intervalStart, chargeableEvent, fareInfo, FareIncrementKindTime)
+
+	result.Success = true
+	result.Message = "停止処理完了"
+	result.FareChange = tm.CurrentFare - oldFare
+	result.LogMessages = append(result.LogMessages, "🛑 停止中 - 時間制料金加算")
+	if fareInfo.Amount > 0 {
+		result.LogMessages = append(result.LogMessages, fmt.Sprintf("   時間料金 +%d円 (現在: %d円)", fareInfo.Amount, tm.CurrentFare))
+	}
+	result.LogMessages = append(result.LogMessages, modifierLogMessages(fareInfo)...)
+
+	return result
+}
+
+// recordIncrement は課金が発生した区間を払戻計算用に記録する。金額ゼロの区間は記録しない。
+func (tm *TaxiMeter) recordIncrement(startAt time.Time, event TripEvent, info FareCalculationInfo, kind FareIncrementKind) {
+	if info.Amount == 0 {
+		return
+	}
+
+	increment := FareIncrement{
+		StartAt: startAt,
+		EndAt:   event.Timestamp,
+		Amount:  info.Amount,
+		Reason:  info.Reason,
+		Kind:    kind,
+	}
+	switch kind {
+	case FareIncrementKindDistance:
+		increment.Distance = event.Distance
+	case FareIncrementKindTime:
+		increment.Duration = event.Duration
+	}
+
+	tm.Increments = append(tm.Increments, increment)
+}
+
+// modifierLogMessages は発火した PricingModifier をEventResult.LogMessagesに反映するための行を組み立てる
+func modifierLogMessages(info FareCalculationInfo) []string {
+	if len(info.FiredModifiers) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("   ⚡ サージ適用: %s", strings.Join(info.FiredModifiers, ", "))}
+}
+
+func (tm *TaxiMeter) endTrip(event TripEvent) EventResult {
+	var result EventResult
+	result.LogMessages = make([]string, 0)
+
+	if !tm.IsRunning {
+		result.Success = false
+		result.Error = fmt.Errorf("trip not started")
+		result.Message = "終了処理に失敗：メータが開始されていません"
+		return result
+	}
+
+	tm.IsRunning = false
+
+	if name, applied := tm.applyAirportCorridor(event.Timestamp); applied {
+		result.LogMessages = append(result.LogMessages, fmt.Sprintf("✈️  空港定額区間適用: %s - %d円", name, tm.CurrentFare))
+	}
+	if reason, applied := tm.applyFareLimits(event.Timestamp); applied {
+		result.LogMessages = append(result.LogMessages, fmt.Sprintf("📏 %s適用: %d円", reason, tm.CurrentFare))
+	}
+
+	result.Success = true
+	result.Message = "メータ終了"
+	result.LogMessages = append(result.LogMessages, "🏁 乗車終了")
+	result.LogMessages = append(result.LogMessages, tm.generateSummaryMessages()...)
+
+	return result
+}
+
+// applyAirportCorridor は乗車開始地点・終了時点の既知地点がいずれかの空港定額区間に
+// 収まっている場合、運賃をその定額に置き換える
+func (tm *TaxiMeter) applyAirportCorridor(at time.Time) (name string, applied bool) {
+	if tm.originLocation == nil || tm.lastLocation == nil {
+		return "", false
+	}
+
+	for _, corridor := range tm.Config.AirportCorridors {
+		if corridor.Origin.Contains(*tm.originLocation) && corridor.Destination.Contains(*tm.lastLocation) {
+			tm.setFare(corridor.FlatFare, at, fmt.Sprintf("空港定額区間: %s", corridor.Name))
+			return corridor.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// applyFareLimits は MinimumFare/MaximumFare による運賃の下限・上限を適用する
+func (tm *TaxiMeter) applyFareLimits(at time.Time) (reason string, applied bool) {
+	if tm.Config.MinimumFare > 0 && tm.CurrentFare < tm.Config.MinimumFare {
+		tm.setFare(tm.Config.MinimumFare, at, "最低料金")
+		return "最低料金", true
+	}
+	if tm.Config.MaximumFare > 0 && tm.CurrentFare > tm.Config.MaximumFare {
+		tm.setFare(tm.Config.MaximumFare, at, "上限料金")
+		return "上限料金", true
+	}
+	return "", false
+}
+
+// setFare は定額区間・最低/上限料金による事後調整を、払戻計算にも反映されるよう
+// FareIncrement として記録した上でCurrentFareを書き換える
+func (tm *TaxiMeter) setFare(newFare int, at time.Time, reason string) {
+	delta := newFare - tm.CurrentFare
+	if delta == 0 {
+		return
+	}
+	tm.CurrentFare = newFare
+	tm.Increments = append(tm.Increments, FareIncrement{
+		StartAt: at,
+		EndAt:   at,
+		Amount:  delta,
+		Reason:  reason,
+		Kind:    FareIncrementKindAdjustment,
+	})
+}
+
+func (tm *TaxiMeter) calculateDistanceFare(event TripEvent) FareCalculationInfo {
+	if len(tm.Config.DistanceTiers) > 0 {
+		return tm.calculateTieredDistanceFare(event)
+	}
+
+	if tm.TotalDistance <= tm.Config.InitialDistance {
+		return FareCalculationInfo{Amount: 0, Units: 0, Reason: "初乗り距離内"}
+	}
+
+	chargeableDistance := tm.TotalDistance - tm.Config.InitialDistance
+	units := int(chargeableDistance / tm.Config.UnitDistance)
+
+	// 前回の計算からの差分のみ加算
+	previousDistance := tm.TotalDistance - event.Distance
+	previousChargeableDistance := previousDistance - tm.Config.InitialDistance
+	if previousChargeableDistance < 0 {
+		previousChargeableDistance = 0
+	}
+	previousUnits := int(previousChargeableDistance / tm.Config.UnitDistance)
+
+	additionalUnits := units - previousUnits
+	if additionalUnits <= 0 {
+		return FareCalculationInfo{Amount: 0, Units: 0, Reason: "追加単位なし"}
+	}
+
+	return tm.applyModifiers(event, FareCalculationInfo{
+		Amount: additionalUnits * tm.Config.UnitFare,
+		Units:  additionalUnits,
+		Reason: fmt.Sprintf("%d単位追加", additionalUnits),
+	})
+}
+
+// calculateTieredDistanceFare は Config.DistanceTiers の帯ごとの単価を使って距離制料金を計算する。
+// 初乗り距離内であっても帯の開始距離として扱われ、InitialDistance は初乗り料金のみに使われる。
+func (tm *TaxiMeter) calculateTieredDistanceFare(event TripEvent) FareCalculationInfo {
+	current := tieredFare(tm.TotalDistance, tm.Config.DistanceTiers)
+	previous := tieredFare(tm.TotalDistance-event.Distance, tm.Config.DistanceTiers)
+	amount := current - previous
+	if amount <= 0 {
+		return FareCalculationInfo{Amount: 0, Units: 0, Reason: "追加課金なし"}
+	}
+
+	return tm.applyModifiers(event, FareCalculationInfo{
+		Amount: amount,
+		Reason: "距離帯課金",
+	})
+}
+
+// tieredFare は 0 から totalKm までの累計距離に対する、距離帯ごとの単価を使った運賃を計算する
+func tieredFare(totalKm float64, tiers []DistanceTier) int {
+	if totalKm <= 0 {
+		return 0
+	}
+
+	remaining := totalKm
+	lowerBound := 0.0
+	total := 0.0
+
+	for _, tier := range tiers {
+		span := remaining
+		if tier.UpToKm > lowerBound {
+			span = math.Min(remaining, tier.UpToKm-lowerBound)
+		}
+		if span <= 0 {
+			break
+		}
+
+		total += span * float64(tier.RatePerKm)
+		remaining -= span
+		lowerBound = tier.UpToKm
+
+		if remaining <= 0 {
+			break
+		}
+	}
+
+	return int(math.Round(total))
+}
+
+func (tm *TaxiMeter) calculateTimeFare(event TripEvent) FareCalculationInfo {
+	units := int(event.Duration / tm.Config.TimeUnit)
+	if units <= 0 {
+		return FareCalculationInfo{Amount: 0, Units: 0, Reason: "時間単位未満"}
+	}
+
+	return tm.applyModifiers(event, FareCalculationInfo{
+		Amount: units * tm.Config.TimeUnitFare,
+		Units:  units,
+		Reason: fmt.Sprintf("%d時間単位", units),
+	})
+}
+
+// applyModifiers は Config.Modifiers を順に適用し、発火したモディファイアを記録する
+func (tm *TaxiMeter) applyModifiers(event TripEvent, info FareCalculationInfo) FareCalculationInfo {
+	if info.Amount == 0 || len(tm.Config.Modifiers) == 0 {
+		return info
+	}
+
+	ctx := PricingContext{Now: event.Timestamp, Location: event.Location}
+	amount := float64(info.Amount)
+
+	for _, modifier := range tm.Config.Modifiers {
+		factor, fired := modifier.Multiplier(ctx)
+		if !fired {
+			continue
+		}
+		amount *= factor
+		info.FiredModifiers = append(info.FiredModifiers, fmt.Sprintf("%s(x%.2f)", modifier.Name(), factor))
+	}
+
+	info.Amount = int(math.Round(amount))
+	return info
+}
+
+func (tm *TaxiMeter) generateSummaryMessages() []string {
+	return []string{
+		fmt.Sprintf("総距離: %.2f km", tm.TotalDistance),
+		fmt.Sprintf("総時間: %v", tm.TotalTime),
+		fmt.Sprintf("最終料金: %d円", tm.CurrentFare),
+	}
+}
+
+func (tm *TaxiMeter) GetCurrentFare() int {
+	return tm.CurrentFare
+}
+
+func (tm *TaxiMeter) GetTotalDistance() float64 {
+	return tm.TotalDistance
+}
@@ -0,0 +1,181 @@
+package meter
+
+import "time"
+
+// EventResult はメータ/セッションへのイベント適用結果を表す
+type EventResult struct {
+	Success      bool
+	Message      string
+	FareChange   int      // 料金の変更額
+	NewTotalFare int      // 新しい合計料金
+	LogMessages  []string // ログメッセージ
+	Error        error
+}
+
+// ProcessResult は一連のイベントをまとめて処理した結果を表す
+type ProcessResult struct {
+	EventResults []EventResult
+	FinalFare    int
+	SessionInfo  map[string]any
+}
+
+// TripEvent はメータに入力される1つの乗車イベントを表す
+type TripEvent struct {
+	EventType TripEventType // "start", "move", "stop", "end"
+	Timestamp time.Time     // イベント発生時刻
+	Distance  float64       // この区間での移動距離 (km)
+	Duration  time.Duration // この区間での経過時間
+	Speed     float64       // この区間での平均速度 (km/h)
+	Location  *LatLon       // 現在地 (ゾーンサージ判定用、不明な場合は nil)
+
+	PassengerCount int // 乗客数 (Startイベントでのみ意味を持つ、0は1人とみなす)
+	Toll           int // この区間で発生した高速料金等 (Moveイベントでのみ意味を持つ、TollPassThrough時に加算)
+}
+
+type TripEventType int
+
+const (
+	TripEventTypeStart = iota + 1
+	TripEventTypeMove
+	TripEventTypeStop
+	TripEventTypeEnd
+)
+
+// FareConfig は運賃計算に用いる料金テーブルを表す
+type FareConfig struct {
+	Currency        string        // 決済に使う通貨コード (例: "JPY"、省略時は "JPY" とみなす)
+	InitialFare     int           // 初乗り料金
+	InitialDistance float64       // 初乗り距離 (km)
+	UnitFare        int           // 単位料金
+	UnitDistance    float64       // 単位距離 (km)
+	TimeThreshold   float64       // 時間制に切り替わる速度閾値 (km/h)
+	TimeUnitFare    int           // 時間制単位料金
+	TimeUnit        time.Duration // 時間制単位時間
+
+	Modifiers []PricingModifier // サージ/時間帯サーチャージ等、運賃増分に適用するモディファイア (任意)
+
+	// DistanceTiers が非空の場合、UnitFare/UnitDistance の単一単価方式の代わりに
+	// 距離帯ごとの単価 (例: 最初の2kmはA円/km、以降はB円/km) で距離制料金を計算する。
+	DistanceTiers []DistanceTier
+
+	MinimumFare int // 最低料金 (0は無効)
+	MaximumFare int // 上限料金 (0は無効)
+
+	PerPassengerSurcharge int // 2人目以降の乗客1人あたりの追加料金
+
+	TollPassThrough bool // 区間ごとのTripEvent.Tollをそのまま運賃に転嫁するか
+
+	WaitingGracePeriod time.Duration // 停車時間のうち、時間制料金が発生し始めるまでの猶予
+
+	AirportCorridors []AirportCorridor // 空港発着等、OriginとDestinationの組で定額になる区間 (任意)
+}
+
+// DistanceTier は距離帯ごとの単価を表す。UpToKm<=0 は「これ以降の距離すべて」を意味する
+// 最終帯として扱われる。
+type DistanceTier struct {
+	UpToKm    float64 // この帯が適用される累計距離の上限 (km)
+	RatePerKm int     // この帯での1kmあたりの料金
+}
+
+// AirportCorridor は Origin から Destination への乗車を定額運賃にする区間を表す
+type AirportCorridor struct {
+	Name        string
+	Origin      Polygon
+	Destination Polygon
+	FlatFare    int
+}
+
+// SessionStatus は乗車セッションの状態を表す
+type SessionStatus string
+
+const (
+	StatusWaiting   SessionStatus = "waiting"    // 配車待ち
+	StatusPickingUp SessionStatus = "picking_up" // 迎車中
+	StatusOnboard   SessionStatus = "onboard"    // 乗車中
+	StatusCompleted SessionStatus = "completed"  // 完了
+	StatusCancelled SessionStatus = "cancelled"  // キャンセル
+)
+
+type Driver struct {
+	ID   string
+	Name string
+}
+
+type Passenger struct {
+	ID   string
+	Name string
+}
+
+// PaymentInfo は1回の決済の現在状態と、クラッシュ後の決済再開に使う遷移履歴を保持する
+type PaymentInfo struct {
+	Method         PaymentMethod
+	Amount         int
+	ProcessedAt    *time.Time
+	Provider       string // 決済を処理した PaymentProvider.Name()
+	ProviderRef    string // プロバイダ側の与信/確定ID
+	IdempotencyKey string // ProcessPayment の冪等キー (リトライによる二重課金を防ぐ)
+	Status         PaymentStatus
+	History        []PaymentTransition
+}
+
+// recordTransition は決済状態の遷移を履歴に追加する
+func (p *PaymentInfo) recordTransition(status PaymentStatus, at time.Time) {
+	p.Status = status
+	p.History = append(p.History, PaymentTransition{Status: status, At: at})
+}
+
+// PaymentTransition は PaymentInfo.History の1エントリで、決済状態が変化した時刻を記録する
+type PaymentTransition struct {
+	Status PaymentStatus
+	At     time.Time
+}
+
+type PaymentMethod string
+
+const (
+	PaymentMethodCash    = "cash"
+	PaymentMethodCard    = "card"
+	PaymentMethodDigital = "digital"
+)
+
+// PaymentStatus は PaymentProvider を通した決済のライフサイクル上の状態を表す
+type PaymentStatus string
+
+const (
+	PaymentStatusAuthorized PaymentStatus = "authorized"
+	PaymentStatusCaptured   PaymentStatus = "captured"
+	PaymentStatusVoided     PaymentStatus = "voided"
+	PaymentStatusRefunded   PaymentStatus = "refunded"
+	PaymentStatusFailed     PaymentStatus = "failed"
+)
+
+// FareCalculationInfo は運賃計算1回分の明細を表す
+type FareCalculationInfo struct {
+	Amount         int
+	Units          int
+	Reason         string
+	FiredModifiers []string // 適用されたPricingModifierの名前 (倍率付き)
+}
+
+// FareIncrementKind は FareIncrement がどの料金体系で課金されたかを表す
+type FareIncrementKind int
+
+const (
+	FareIncrementKindInitial FareIncrementKind = iota + 1
+	FareIncrementKindDistance
+	FareIncrementKindTime
+	FareIncrementKindSurcharge  // 乗客追加料金等の定額加算
+	FareIncrementKindToll       // 高速料金等のパススルー
+	FareIncrementKindAdjustment // 定額区間・最低/上限料金による事後調整
+)
+
+// FareIncrement は課金済みの運賃増分1件分の明細で、Refund の払戻計算の元データになる
+type FareIncrement struct {
+	StartAt  time.Time
+	EndAt    time.Time
+	Amount   int
+	Reason   string
+	Kind     FareIncrementKind
+	Distance float64       // この増分が対応する距離 (km)、Kind=Distanceのみ意味を持つ
+	Duration time.Duration // この増分が対応する時間、Kind=Timeのみ意味を持つ
+}
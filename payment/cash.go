@@ -0,0 +1,49 @@
+// Package payment provides concrete meter.PaymentProvider implementations:
+// cash settled in person, card payments via Stripe, and digital wallets
+// such as LINE Pay / PayPay.
+package payment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/szks-repo/taxi-meter-go/meter"
+)
+
+// CashProvider はその場で現金授受が行われる決済を表す。
+// 与信・確定は運転手の目視確認を模した即時成功として扱う。
+type CashProvider struct{}
+
+func NewCashProvider() *CashProvider {
+	return &CashProvider{}
+}
+
+func (p *CashProvider) Name() string {
+	return "cash"
+}
+
+func (p *CashProvider) Method() meter.PaymentMethod {
+	return meter.PaymentMethodCash
+}
+
+func (p *CashProvider) Authorize(_ context.Context, req meter.AuthorizeRequest) (meter.AuthorizeResult, error) {
+	return meter.AuthorizeResult{
+		ProviderRef: fmt.Sprintf("cash-%s", req.IdempotencyKey),
+		Status:      meter.PaymentStatusAuthorized,
+	}, nil
+}
+
+func (p *CashProvider) Capture(_ context.Context, req meter.CaptureRequest) (meter.CaptureResult, error) {
+	return meter.CaptureResult{
+		ProviderRef: req.ProviderRef,
+		Status:      meter.PaymentStatusCaptured,
+	}, nil
+}
+
+func (p *CashProvider) Void(context.Context, meter.VoidRequest) (meter.VoidResult, error) {
+	return meter.VoidResult{Status: meter.PaymentStatusVoided}, nil
+}
+
+func (p *CashProvider) Refund(context.Context, meter.GatewayRefundRequest) (meter.GatewayRefundResult, error) {
+	return meter.GatewayRefundResult{Status: meter.PaymentStatusRefunded}, nil
+}
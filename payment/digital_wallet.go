@@ -0,0 +1,148 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/szks-repo/taxi-meter-go/meter"
+)
+
+// WalletKind はどの電子ウォレット事業者のAPIを叩くかを表す
+type WalletKind string
+
+const (
+	WalletKindLinePay WalletKind = "line_pay"
+	WalletKindPayPay  WalletKind = "paypay"
+)
+
+// DigitalWalletProvider は LINE Pay / PayPay のような、予約(Authorize)と確定(Capture)が
+// 別APIコールになっている電子ウォレット決済を扱う。
+type DigitalWalletProvider struct {
+	Kind       WalletKind
+	BaseURL    string // 事業者ごとのAPIベースURL
+	ChannelID  string
+	APIKey     string
+	HTTPClient *http.Client
+}
+
+func NewDigitalWalletProvider(kind WalletKind, baseURL, channelID, apiKey string) *DigitalWalletProvider {
+	return &DigitalWalletProvider{
+		Kind:       kind,
+		BaseURL:    baseURL,
+		ChannelID:  channelID,
+		APIKey:     apiKey,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+func (p *DigitalWalletProvider) Name() string {
+	return string(p.Kind)
+}
+
+func (p *DigitalWalletProvider) Method() meter.PaymentMethod {
+	return meter.PaymentMethodDigital
+}
+
+func (p *DigitalWalletProvider) Authorize(ctx context.Context, req meter.AuthorizeRequest) (meter.AuthorizeResult, error) {
+	var resp walletReservationResponse
+	body := map[string]any{
+		"amount":         req.Amount,
+		"currency":       req.Currency,
+		"orderId":        req.SessionID,
+		"idempotencyKey": req.IdempotencyKey,
+	}
+	if err := p.do(ctx, req.IdempotencyKey, "POST", "/payments/requests", body, &resp); err != nil {
+		return meter.AuthorizeResult{}, err
+	}
+
+	return meter.AuthorizeResult{
+		ProviderRef: resp.ReservationID,
+		Status:      meter.PaymentStatusAuthorized,
+	}, nil
+}
+
+func (p *DigitalWalletProvider) Capture(ctx context.Context, req meter.CaptureRequest) (meter.CaptureResult, error) {
+	var resp walletConfirmResponse
+	body := map[string]any{"amount": req.Amount}
+	path := fmt.Sprintf("/payments/%s/confirm", req.ProviderRef)
+	if err := p.do(ctx, req.IdempotencyKey, "POST", path, body, &resp); err != nil {
+		return meter.CaptureResult{}, err
+	}
+
+	return meter.CaptureResult{
+		ProviderRef: req.ProviderRef,
+		Status:      meter.PaymentStatusCaptured,
+	}, nil
+}
+
+func (p *DigitalWalletProvider) Void(ctx context.Context, req meter.VoidRequest) (meter.VoidResult, error) {
+	path := fmt.Sprintf("/payments/%s/cancel", req.ProviderRef)
+	if err := p.do(ctx, "", "POST", path, nil, &struct{}{}); err != nil {
+		return meter.VoidResult{}, err
+	}
+	return meter.VoidResult{Status: meter.PaymentStatusVoided}, nil
+}
+
+func (p *DigitalWalletProvider) Refund(ctx context.Context, req meter.GatewayRefundRequest) (meter.GatewayRefundResult, error) {
+	path := fmt.Sprintf("/payments/%s/refund", req.ProviderRef)
+	body := map[string]any{"amount": req.Amount}
+	if err := p.do(ctx, "", "POST", path, body, &struct{}{}); err != nil {
+		return meter.GatewayRefundResult{}, err
+	}
+	return meter.GatewayRefundResult{Status: meter.PaymentStatusRefunded}, nil
+}
+
+type walletReservationResponse struct {
+	ReservationID string `json:"reservationId"`
+}
+
+type walletConfirmResponse struct {
+	Status string `json:"status"`
+}
+
+func (p *DigitalWalletProvider) do(ctx context.Context, idempotencyKey, method, path string, body any, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.BaseURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Channel-Id", p.ChannelID)
+	req.Header.Set("Authorization", "Bearer "+p.APIKey)
+	if idempotencyKey != "" {
+		req.Header.Set("X-Idempotency-Key", idempotencyKey)
+	}
+
+	client := p.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", p.Kind, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		raw, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s: unexpected status %d: %s", p.Kind, resp.StatusCode, raw)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,166 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/szks-repo/taxi-meter-go/meter"
+)
+
+// StripeProvider はカード決済を Stripe の PaymentIntents API 経由で処理する。
+// Authorize は capture_method=manual な PaymentIntent の作成・確定 (与信のみ) に、
+// Capture はその PaymentIntent の capture に対応する。
+type StripeProvider struct {
+	SecretKey  string
+	BaseURL    string // 省略時は https://api.stripe.com/v1
+	HTTPClient *http.Client
+}
+
+func NewStripeProvider(secretKey string) *StripeProvider {
+	return &StripeProvider{
+		SecretKey:  secretKey,
+		BaseURL:    "https://api.stripe.com/v1",
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+func (p *StripeProvider) Name() string {
+	return "stripe"
+}
+
+func (p *StripeProvider) Method() meter.PaymentMethod {
+	return meter.PaymentMethodCard
+}
+
+func (p *StripeProvider) Authorize(ctx context.Context, req meter.AuthorizeRequest) (meter.AuthorizeResult, error) {
+	form := url.Values{
+		"amount":               {strconv.Itoa(req.Amount)},
+		"currency":             {strings.ToLower(req.Currency)},
+		"capture_method":       {"manual"},
+		"confirm":              {"true"},
+		"payment_method":       {"pm_card_visa"}, // デモ用の既定PaymentMethod。実運用では乗客側で作成したものを渡す
+		"metadata[session_id]": {req.SessionID},
+	}
+
+	var intent stripePaymentIntent
+	if err := p.do(ctx, req.IdempotencyKey, "POST", "/payment_intents", form, &intent); err != nil {
+		return meter.AuthorizeResult{}, err
+	}
+
+	return meter.AuthorizeResult{
+		ProviderRef: intent.ID,
+		Status:      stripeStatusToPaymentStatus(intent.Status, meter.PaymentStatusAuthorized),
+	}, nil
+}
+
+func (p *StripeProvider) Capture(ctx context.Context, req meter.CaptureRequest) (meter.CaptureResult, error) {
+	form := url.Values{"amount_to_capture": {strconv.Itoa(req.Amount)}}
+
+	var intent stripePaymentIntent
+	path := fmt.Sprintf("/payment_intents/%s/capture", req.ProviderRef)
+	if err := p.do(ctx, req.IdempotencyKey, "POST", path, form, &intent); err != nil {
+		return meter.CaptureResult{}, err
+	}
+
+	return meter.CaptureResult{
+		ProviderRef: intent.ID,
+		Status:      stripeStatusToPaymentStatus(intent.Status, meter.PaymentStatusCaptured),
+	}, nil
+}
+
+func (p *StripeProvider) Void(ctx context.Context, req meter.VoidRequest) (meter.VoidResult, error) {
+	path := fmt.Sprintf("/payment_intents/%s/cancel", req.ProviderRef)
+
+	var intent stripePaymentIntent
+	if err := p.do(ctx, "", "POST", path, nil, &intent); err != nil {
+		return meter.VoidResult{}, err
+	}
+
+	return meter.VoidResult{Status: meter.PaymentStatusVoided}, nil
+}
+
+func (p *StripeProvider) Refund(ctx context.Context, req meter.GatewayRefundRequest) (meter.GatewayRefundResult, error) {
+	form := url.Values{
+		"payment_intent": {req.ProviderRef},
+		"amount":         {strconv.Itoa(req.Amount)},
+	}
+
+	var refund struct {
+		Status string `json:"status"`
+	}
+	if err := p.do(ctx, "", "POST", "/refunds", form, &refund); err != nil {
+		return meter.GatewayRefundResult{}, err
+	}
+
+	return meter.GatewayRefundResult{Status: meter.PaymentStatusRefunded}, nil
+}
+
+type stripePaymentIntent struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func stripeStatusToPaymentStatus(stripeStatus string, fallback meter.PaymentStatus) meter.PaymentStatus {
+	switch stripeStatus {
+	case "requires_capture":
+		return meter.PaymentStatusAuthorized
+	case "succeeded":
+		return meter.PaymentStatusCaptured
+	case "canceled":
+		return meter.PaymentStatusVoided
+	default:
+		return fallback
+	}
+}
+
+func (p *StripeProvider) do(ctx context.Context, idempotencyKey, method, path string, form url.Values, out any) error {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.baseURL()+path, body)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(p.SecretKey, "")
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := p.client().Do(req)
+	if err != nil {
+		return fmt.Errorf("stripe: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		raw, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("stripe: unexpected status %d: %s", resp.StatusCode, raw)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (p *StripeProvider) baseURL() string {
+	if p.BaseURL != "" {
+		return p.BaseURL
+	}
+	return "https://api.stripe.com/v1"
+}
+
+func (p *StripeProvider) client() *http.Client {
+	if p.HTTPClient != nil {
+		return p.HTTPClient
+	}
+	return http.DefaultClient
+}
@@ -0,0 +1,73 @@
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/szks-repo/taxi-meter-go/meter"
+)
+
+// goldenTrip is a fixed, region-agnostic sequence of TripEvents replayed
+// against every built-in ruleset. The timestamps sit at midday so no
+// region's night_surcharge fires, and no Location is set so no
+// airport_corridors flat fare is triggered either: these tests pin down the
+// ordinary tiered distance/time fare math the rulesets were written for.
+func goldenTrip() []meter.TripEvent {
+	base := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	return []meter.TripEvent{
+		{EventType: meter.TripEventTypeStart, Timestamp: base, PassengerCount: 1},
+		{EventType: meter.TripEventTypeMove, Timestamp: base.Add(10 * time.Minute), Distance: 5.0, Duration: 10 * time.Minute, Speed: 30.0, Toll: 300},
+		{EventType: meter.TripEventTypeStop, Timestamp: base.Add(12 * time.Minute), Duration: 2 * time.Minute},
+		{EventType: meter.TripEventTypeEnd, Timestamp: base.Add(14 * time.Minute)},
+	}
+}
+
+// TestGoldenRuleSets replays goldenTrip through every embedded ruleset and
+// checks the resulting fare against a value computed once from the compiled
+// FareConfig. A change here means either a rulesets/*.yaml edit or a
+// meter fare-calculation change moved a region's numbers; both should be
+// called out explicitly in the PR that touches this test.
+func TestGoldenRuleSets(t *testing.T) {
+	registry := NewRegistry()
+
+	tests := []struct {
+		region    string
+		currency  string
+		wantFare  int // fare after the Start event (initial fare + any passenger surcharge)
+		wantFinal int // fare after the full trip (Start+Move+Stop+End)
+	}{
+		{region: "tokyo", currency: "JPY", wantFare: 500, wantFinal: 2447},
+		{region: "nyc", currency: "USD", wantFare: 300, wantFinal: 1924},
+		{region: "london", currency: "GBP", wantFare: 370, wantFinal: 2020},
+		{region: "berlin", currency: "EUR", wantFare: 390, wantFinal: 1490},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.region, func(t *testing.T) {
+			cfg, err := registry.LoadRuleSet(tt.region)
+			if err != nil {
+				t.Fatalf("LoadRuleSet(%q): %v", tt.region, err)
+			}
+			if cfg.Currency != tt.currency {
+				t.Fatalf("Currency = %q, want %q", cfg.Currency, tt.currency)
+			}
+
+			session := meter.NewRideSession("golden-"+tt.region, meter.Driver{ID: "d1", Name: "Driver"}, meter.Passenger{ID: "p1", Name: "Passenger"}, cfg)
+
+			var last meter.EventResult
+			for i, event := range goldenTrip() {
+				last = session.Meter.ProcessEvent(event)
+				if !last.Success {
+					t.Fatalf("event %d (%v) failed: %v", i, event.EventType, last.Error)
+				}
+				if event.EventType == meter.TripEventTypeStart && session.Meter.CurrentFare != tt.wantFare {
+					t.Fatalf("fare after Start = %d, want %d", session.Meter.CurrentFare, tt.wantFare)
+				}
+			}
+
+			if got := session.Meter.CurrentFare; got != tt.wantFinal {
+				t.Errorf("final fare = %d, want %d", got, tt.wantFinal)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package rules
+
+import (
+	"embed"
+	"fmt"
+	"sync"
+
+	"github.com/szks-repo/taxi-meter-go/meter"
+)
+
+//go:embed rulesets/*.yaml
+var builtinRuleSets embed.FS
+
+// RuleSetRegistry holds compiled meter.FareConfig values keyed by region.
+type RuleSetRegistry struct {
+	mu      sync.RWMutex
+	configs map[string]meter.FareConfig
+}
+
+// NewRegistry parses every ruleset embedded in this package (rulesets/*.yaml)
+// and returns a registry ready to serve LoadRuleSet. It panics if a built-in
+// ruleset fails to parse, since that indicates a bug in this package rather
+// than bad user input.
+func NewRegistry() *RuleSetRegistry {
+	entries, err := builtinRuleSets.ReadDir("rulesets")
+	if err != nil {
+		panic(fmt.Sprintf("rules: read embedded rulesets: %v", err))
+	}
+
+	configs := make(map[string]meter.FareConfig, len(entries))
+	for _, entry := range entries {
+		raw, err := builtinRuleSets.ReadFile("rulesets/" + entry.Name())
+		if err != nil {
+			panic(fmt.Sprintf("rules: read %s: %v", entry.Name(), err))
+		}
+
+		spec, err := ParseRuleSetSpec(raw)
+		if err != nil {
+			panic(fmt.Sprintf("rules: compile %s: %v", entry.Name(), err))
+		}
+
+		configs[spec.Region] = spec.Compile()
+	}
+
+	return &RuleSetRegistry{configs: configs}
+}
+
+// LoadRuleSet returns the compiled FareConfig for region (e.g. "tokyo",
+// "nyc", "london", "berlin").
+func (r *RuleSetRegistry) LoadRuleSet(region string) (meter.FareConfig, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cfg, ok := r.configs[region]
+	if !ok {
+		return meter.FareConfig{}, fmt.Errorf("rules: no ruleset for region %q", region)
+	}
+	return cfg, nil
+}
+
+// Register adds or replaces a compiled ruleset at runtime, e.g. one loaded
+// from an operator-managed YAML file outside this package.
+func (r *RuleSetRegistry) Register(region string, cfg meter.FareConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.configs[region] = cfg
+}
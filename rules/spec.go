@@ -0,0 +1,136 @@
+// Package rules compiles region-specific fare tariffs, declared as a small
+// YAML DSL, into meter.FareConfig values that TaxiMeter consults directly.
+// This keeps the meter package itself region-agnostic: it knows how to
+// apply distance tiers, surcharges, grace periods and flat corridors, but
+// has no notion of where those numbers come from.
+package rules
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/szks-repo/taxi-meter-go/meter"
+	"gopkg.in/yaml.v3"
+)
+
+// RuleSetSpec is the YAML-facing shape of a single region's tariff DSL.
+// A Lua-scripted ruleset could populate the same struct and reuse Compile;
+// that path is not implemented here, only the YAML one.
+type RuleSetSpec struct {
+	Region   string `yaml:"region"`
+	Currency string `yaml:"currency"`
+
+	InitialFare       int     `yaml:"initial_fare"`
+	InitialDistanceKm float64 `yaml:"initial_distance_km"`
+
+	DistanceTiers []DistanceTierSpec `yaml:"distance_tiers"`
+
+	TimeThresholdKmh float64 `yaml:"time_threshold_kmh"`
+	TimeUnitSeconds  float64 `yaml:"time_unit_seconds"`
+	TimeUnitFare     int     `yaml:"time_unit_fare"`
+
+	MinimumFare           int  `yaml:"minimum_fare"`
+	MaximumFare           int  `yaml:"maximum_fare"`
+	PerPassengerSurcharge int  `yaml:"per_passenger_surcharge"`
+	TollPassThrough       bool `yaml:"toll_pass_through"`
+
+	WaitingGracePeriodSeconds float64 `yaml:"waiting_grace_period_seconds"`
+
+	NightSurcharge   *NightSurchargeSpec   `yaml:"night_surcharge"`
+	AirportCorridors []AirportCorridorSpec `yaml:"airport_corridors"`
+}
+
+// DistanceTierSpec is one band of a progressive per-km rate table.
+// UpToKm<=0 marks the final, unbounded band.
+type DistanceTierSpec struct {
+	UpToKm    float64 `yaml:"up_to_km"`
+	RatePerKm int     `yaml:"rate_per_km"`
+}
+
+// NightSurchargeSpec declares a time-of-day surcharge window, compiled to a
+// meter.TimeOfDayModifier.
+type NightSurchargeSpec struct {
+	StartHour int     `yaml:"start_hour"`
+	EndHour   int     `yaml:"end_hour"`
+	Factor    float64 `yaml:"factor"`
+}
+
+// LatLonSpec is a single polygon vertex in the YAML DSL.
+type LatLonSpec struct {
+	Lat float64 `yaml:"lat"`
+	Lon float64 `yaml:"lon"`
+}
+
+// AirportCorridorSpec declares a flat-fare corridor between an origin and a
+// destination polygon (e.g. an airport terminal zone and the city center).
+type AirportCorridorSpec struct {
+	Name        string       `yaml:"name"`
+	FlatFare    int          `yaml:"flat_fare"`
+	Origin      []LatLonSpec `yaml:"origin"`
+	Destination []LatLonSpec `yaml:"destination"`
+}
+
+// ParseRuleSetSpec decodes a single region's YAML tariff document.
+func ParseRuleSetSpec(yamlBytes []byte) (RuleSetSpec, error) {
+	var spec RuleSetSpec
+	if err := yaml.Unmarshal(yamlBytes, &spec); err != nil {
+		return RuleSetSpec{}, fmt.Errorf("rules: parse ruleset: %w", err)
+	}
+	if spec.Region == "" {
+		return RuleSetSpec{}, fmt.Errorf("rules: ruleset is missing a region")
+	}
+	return spec, nil
+}
+
+// Compile converts the DSL spec into the meter.FareConfig that TaxiMeter
+// consults during a ride.
+func (s RuleSetSpec) Compile() meter.FareConfig {
+	cfg := meter.FareConfig{
+		Currency:              s.Currency,
+		InitialFare:           s.InitialFare,
+		InitialDistance:       s.InitialDistanceKm,
+		TimeThreshold:         s.TimeThresholdKmh,
+		TimeUnitFare:          s.TimeUnitFare,
+		TimeUnit:              time.Duration(s.TimeUnitSeconds * float64(time.Second)),
+		MinimumFare:           s.MinimumFare,
+		MaximumFare:           s.MaximumFare,
+		PerPassengerSurcharge: s.PerPassengerSurcharge,
+		TollPassThrough:       s.TollPassThrough,
+		WaitingGracePeriod:    time.Duration(s.WaitingGracePeriodSeconds * float64(time.Second)),
+	}
+
+	for _, tier := range s.DistanceTiers {
+		cfg.DistanceTiers = append(cfg.DistanceTiers, meter.DistanceTier{
+			UpToKm:    tier.UpToKm,
+			RatePerKm: tier.RatePerKm,
+		})
+	}
+
+	if s.NightSurcharge != nil {
+		cfg.Modifiers = append(cfg.Modifiers, meter.TimeOfDayModifier{
+			Label:     fmt.Sprintf("%s 深夜割増", s.Region),
+			StartHour: s.NightSurcharge.StartHour,
+			EndHour:   s.NightSurcharge.EndHour,
+			Factor:    s.NightSurcharge.Factor,
+		})
+	}
+
+	for _, corridor := range s.AirportCorridors {
+		cfg.AirportCorridors = append(cfg.AirportCorridors, meter.AirportCorridor{
+			Name:        corridor.Name,
+			FlatFare:    corridor.FlatFare,
+			Origin:      toPolygon(corridor.Origin),
+			Destination: toPolygon(corridor.Destination),
+		})
+	}
+
+	return cfg
+}
+
+func toPolygon(points []LatLonSpec) meter.Polygon {
+	polygon := make(meter.Polygon, len(points))
+	for i, p := range points {
+		polygon[i] = meter.LatLon{Lat: p.Lat, Lon: p.Lon}
+	}
+	return polygon
+}
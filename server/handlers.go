@@ -0,0 +1,171 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/szks-repo/taxi-meter-go/meter"
+)
+
+// handleRides は POST /rides を処理する
+func (s *Server) handleRides(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	tenantID, _ := tenantFromContext(r.Context())
+	cfg, ok := s.fareConfigFor(tenantID)
+	if !ok {
+		writeError(w, http.StatusForbidden, "no fare config for tenant")
+		return
+	}
+
+	var req CreateRideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	sessionID := newSessionID()
+	ride := meter.NewRideSession(
+		sessionID,
+		meter.Driver{ID: req.DriverID, Name: req.DriverName},
+		meter.Passenger{ID: req.PassengerID, Name: req.PassengerName},
+		cfg,
+	).WithEventStore(s.store)
+
+	handle := &rideHandle{
+		tenantID:    tenantID,
+		ride:        ride,
+		subscribers: make(map[chan meter.EventResult]struct{}),
+	}
+
+	s.sessionsMu.Lock()
+	s.sessions[sessionID] = handle
+	s.sessionsMu.Unlock()
+
+	writeJSON(w, http.StatusCreated, CreateRideResponse{SessionID: sessionID})
+}
+
+// handleRideSubroute は /rides/{id}/events, /rides/{id}/payment, /rides/{id}/summary,
+// /rides/{id}/stream を単一のServeMuxエントリから振り分ける
+func (s *Server) handleRideSubroute(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/rides/")
+	parts := strings.SplitN(rest, "/", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	sessionID, subroute := parts[0], parts[1]
+
+	handle, ok := s.getRide(sessionID)
+	if !ok {
+		writeError(w, http.StatusNotFound, "ride not found")
+		return
+	}
+
+	tenantID, _ := tenantFromContext(r.Context())
+	if handle.tenantID != tenantID {
+		writeError(w, http.StatusNotFound, "ride not found")
+		return
+	}
+
+	switch subroute {
+	case "events":
+		s.handleRideEvents(w, r, handle)
+	case "payment":
+		s.handleRidePayment(w, r, handle)
+	case "summary":
+		s.handleRideSummary(w, r, handle)
+	case "stream":
+		s.handleRideStream(w, r, handle)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) handleRideEvents(w http.ResponseWriter, r *http.Request, handle *rideHandle) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req EventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	event, err := req.toTripEvent()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	handle.mu.Lock()
+	result := handle.ride.ProcessEvent(event)
+	handle.mu.Unlock()
+
+	handle.broadcast(result)
+
+	status := http.StatusOK
+	if !result.Success {
+		status = http.StatusConflict
+	}
+	writeJSON(w, status, toEventResponse(result))
+}
+
+func (s *Server) handleRidePayment(w http.ResponseWriter, r *http.Request, handle *rideHandle) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req PaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	provider, ok := s.providerFor(meter.PaymentMethod(req.Method))
+	if !ok {
+		writeError(w, http.StatusBadRequest, "unsupported payment method")
+		return
+	}
+
+	handle.mu.Lock()
+	result := handle.ride.ProcessPayment(r.Context(), provider, req.IdempotencyKey, time.Now())
+	handle.mu.Unlock()
+
+	status := http.StatusOK
+	if !result.Success {
+		status = http.StatusConflict
+	}
+	writeJSON(w, status, toEventResponse(result))
+}
+
+func (s *Server) handleRideSummary(w http.ResponseWriter, r *http.Request, handle *rideHandle) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	handle.mu.Lock()
+	summary := handle.ride.GetSessionSummary()
+	handle.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, summary)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
@@ -0,0 +1,169 @@
+// Package server exposes RideSession lifecycle management over REST, a
+// streaming WebSocket endpoint, and (via proto/taxi_meter.proto) a gRPC
+// contract, turning the single-process meter demo into a multi-tenant
+// fleet backend.
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/szks-repo/taxi-meter-go/meter"
+)
+
+// Server はテナントごとの料金設定と進行中の乗車セッションを保持するHTTPサーバ
+type Server struct {
+	logger *slog.Logger
+
+	tenantsMu sync.RWMutex
+	tenants   map[string]meter.FareConfig // tenantID -> 料金設定
+	tokens    map[string]string           // bearer token -> tenantID
+
+	providers map[meter.PaymentMethod]meter.PaymentProvider // 支払い方法 -> 決済プロバイダ
+
+	webhookSecret string // 決済プロバイダからのwebhook検証用の共有シークレット
+
+	store meter.EventStore // セッションのイベントジャーナル永続化先 (クラッシュ後の決済再開用)
+
+	sessionsMu sync.RWMutex
+	sessions   map[string]*rideHandle // sessionID -> 進行中セッション
+}
+
+// rideHandle は1つの乗車セッションと、そのストリーム購読者を束ねる
+type rideHandle struct {
+	mu          sync.Mutex
+	tenantID    string
+	ride        *meter.RideSession
+	subscribers map[chan meter.EventResult]struct{}
+}
+
+// NewServer は tenants (テナントID -> 料金設定), tokens (Bearerトークン -> テナントID),
+// providers (支払い方法 -> 決済プロバイダ), store (セッションのイベントジャーナル永続化先)
+// をもとにサーバを組み立てる。store が nil の場合は meter.NewMemoryEventStore() を使う
+// (プロセス再起動をまたいだクラッシュ復旧が必要なら meter.NewSQLEventStore を渡す)。
+// logger が nil の場合は slog.Default() を使う。
+func NewServer(tenants map[string]meter.FareConfig, tokens map[string]string, providers map[meter.PaymentMethod]meter.PaymentProvider, webhookSecret string, store meter.EventStore, logger *slog.Logger) *Server {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if store == nil {
+		store = meter.NewMemoryEventStore()
+	}
+	return &Server{
+		logger:        logger,
+		tenants:       tenants,
+		tokens:        tokens,
+		providers:     providers,
+		webhookSecret: webhookSecret,
+		store:         store,
+		sessions:      make(map[string]*rideHandle),
+	}
+}
+
+// Handler はサーバの http.Handler を組み立てる。
+// withLogging は withAuth の内側に置き、テナントIDがcontextに積まれた後のリクエストをログに残す。
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rides", s.withAuth(s.withLogging(s.handleRides)))
+	mux.HandleFunc("/rides/", s.withAuth(s.withLogging(s.handleRideSubroute)))
+	mux.HandleFunc("/webhooks/payments", s.withLogging(s.handlePaymentWebhook))
+	return mux
+}
+
+type tenantIDKey struct{}
+
+// withAuth は Authorization: Bearer <token> を検証し、対応するテナントIDをcontextに積む
+func (s *Server) withAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" {
+			writeError(w, http.StatusUnauthorized, "missing bearer token")
+			return
+		}
+
+		s.tenantsMu.RLock()
+		tenantID, ok := s.tokens[token]
+		s.tenantsMu.RUnlock()
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "invalid bearer token")
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), tenantIDKey{}, tenantID)
+		next(w, r.WithContext(ctx))
+	}
+}
+
+func tenantFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantIDKey{}).(string)
+	return tenantID, ok
+}
+
+// withLogging は各リクエストを構造化ログで記録する
+func (s *Server) withLogging(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		next(w, r)
+		s.logger.Info("request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"tenant", ctxTenantOrUnknown(r.Context()),
+		)
+	}
+}
+
+func ctxTenantOrUnknown(ctx context.Context) string {
+	if tenantID, ok := tenantFromContext(ctx); ok {
+		return tenantID
+	}
+	return "unknown"
+}
+
+func (s *Server) fareConfigFor(tenantID string) (meter.FareConfig, bool) {
+	s.tenantsMu.RLock()
+	defer s.tenantsMu.RUnlock()
+	cfg, ok := s.tenants[tenantID]
+	return cfg, ok
+}
+
+func (s *Server) providerFor(method meter.PaymentMethod) (meter.PaymentProvider, bool) {
+	provider, ok := s.providers[method]
+	return provider, ok
+}
+
+func (s *Server) getRide(sessionID string) (*rideHandle, bool) {
+	s.sessionsMu.RLock()
+	defer s.sessionsMu.RUnlock()
+	handle, ok := s.sessions[sessionID]
+	return handle, ok
+}
+
+// broadcast は処理済みの EventResult をセッションの全購読者に配送する。
+// 購読者のチャネルが詰まっている場合はそのイベントを読み飛ばす (配信はベストエフォート)。
+func (h *rideHandle) broadcast(result meter.EventResult) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subscribers {
+		select {
+		case ch <- result:
+		default:
+		}
+	}
+}
+
+func (h *rideHandle) subscribe() chan meter.EventResult {
+	ch := make(chan meter.EventResult, 16)
+	h.mu.Lock()
+	h.subscribers[ch] = struct{}{}
+	h.mu.Unlock()
+	return ch
+}
+
+func (h *rideHandle) unsubscribe(ch chan meter.EventResult) {
+	h.mu.Lock()
+	delete(h.subscribers, ch)
+	h.mu.Unlock()
+	close(ch)
+}
@@ -0,0 +1,13 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// newSessionID はセッションIDとして使う、"ride-" に続くランダムな16進文字列を生成する
+func newSessionID() string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	return "ride-" + hex.EncodeToString(buf)
+}
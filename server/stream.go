@@ -0,0 +1,30 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"golang.org/x/net/websocket"
+)
+
+// handleRideStream は /rides/{id}/stream をWebSocketにアップグレードし、
+// このセッションで今後処理される EventResult をJSONで逐次プッシュする。
+func (s *Server) handleRideStream(w http.ResponseWriter, r *http.Request, handle *rideHandle) {
+	websocket.Handler(func(ws *websocket.Conn) {
+		defer ws.Close()
+
+		ch := handle.subscribe()
+		defer handle.unsubscribe(ch)
+
+		for result := range ch {
+			payload, err := json.Marshal(toEventResponse(result))
+			if err != nil {
+				s.logger.Warn("stream: failed to marshal event result", "error", err)
+				continue
+			}
+			if err := websocket.Message.Send(ws, string(payload)); err != nil {
+				return
+			}
+		}
+	}).ServeHTTP(w, r)
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/szks-repo/taxi-meter-go/meter"
+)
+
+// CreateRideRequest は POST /rides のリクエストボディを表す
+type CreateRideRequest struct {
+	DriverID      string `json:"driver_id"`
+	DriverName    string `json:"driver_name"`
+	PassengerID   string `json:"passenger_id"`
+	PassengerName string `json:"passenger_name"`
+}
+
+// CreateRideResponse は POST /rides のレスポンスを表す
+type CreateRideResponse struct {
+	SessionID string `json:"session_id"`
+}
+
+// EventRequest は POST /rides/{id}/events のリクエストボディを表す。
+// meter.TripEvent のJSONでの表現で、EventTypeは文字列で受け取る。
+type EventRequest struct {
+	EventType      string    `json:"event_type"` // "start", "move", "stop", "end"
+	Timestamp      time.Time `json:"timestamp"`
+	Distance       float64   `json:"distance_km"`
+	Duration       float64   `json:"duration_seconds"`
+	Speed          float64   `json:"speed_kmh"`
+	Lat            *float64  `json:"lat,omitempty"`
+	Lon            *float64  `json:"lon,omitempty"`
+	PassengerCount int       `json:"passenger_count,omitempty"` // Startイベントでのみ意味を持つ
+	Toll           int       `json:"toll,omitempty"`            // Moveイベントでのみ意味を持つ
+}
+
+// toTripEvent は EventRequest を meter.TripEvent に変換する
+func (r EventRequest) toTripEvent() (meter.TripEvent, error) {
+	eventType, err := parseTripEventType(r.EventType)
+	if err != nil {
+		return meter.TripEvent{}, err
+	}
+
+	event := meter.TripEvent{
+		EventType:      eventType,
+		Timestamp:      r.Timestamp,
+		Distance:       r.Distance,
+		Duration:       time.Duration(r.Duration * float64(time.Second)),
+		Speed:          r.Speed,
+		PassengerCount: r.PassengerCount,
+		Toll:           r.Toll,
+	}
+	if r.Lat != nil && r.Lon != nil {
+		event.Location = &meter.LatLon{Lat: *r.Lat, Lon: *r.Lon}
+	}
+	return event, nil
+}
+
+func parseTripEventType(s string) (meter.TripEventType, error) {
+	switch s {
+	case "start":
+		return meter.TripEventTypeStart, nil
+	case "move":
+		return meter.TripEventTypeMove, nil
+	case "stop":
+		return meter.TripEventTypeStop, nil
+	case "end":
+		return meter.TripEventTypeEnd, nil
+	default:
+		return 0, fmt.Errorf("unknown event_type: %q", s)
+	}
+}
+
+// EventResponse は POST /rides/{id}/events のレスポンスを表す
+type EventResponse struct {
+	Success      bool     `json:"success"`
+	Message      string   `json:"message"`
+	FareChange   int      `json:"fare_change"`
+	NewTotalFare int      `json:"new_total_fare"`
+	LogMessages  []string `json:"log_messages"`
+	Error        string   `json:"error,omitempty"`
+}
+
+func toEventResponse(result meter.EventResult) EventResponse {
+	resp := EventResponse{
+		Success:      result.Success,
+		Message:      result.Message,
+		FareChange:   result.FareChange,
+		NewTotalFare: result.NewTotalFare,
+		LogMessages:  result.LogMessages,
+	}
+	if result.Error != nil {
+		resp.Error = result.Error.Error()
+	}
+	return resp
+}
+
+// PaymentRequest は POST /rides/{id}/payment のリクエストボディを表す
+type PaymentRequest struct {
+	Method         string `json:"method"`          // "cash", "card", "digital"
+	IdempotencyKey string `json:"idempotency_key"` // クライアント側でリトライ時も固定する冪等キー
+}
+
+// errorResponse はエラー応答の共通フォーマット
+type errorResponse struct {
+	Error string `json:"error"`
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/szks-repo/taxi-meter-go/meter"
+)
+
+// paymentWebhookRequest は決済プロバイダから送られる非同期確定通知 (capture confirmation) を表す。
+// プロバイダごとの実際のペイロード形式はまちまちだが、事前にどのプロバイダからの通知か
+// ルーティング済みである前提で、この共通フォーマットに正規化されてから届くものとして扱う。
+type paymentWebhookRequest struct {
+	SessionID   string `json:"session_id"`
+	ProviderRef string `json:"provider_ref"`
+	Status      string `json:"status"` // "authorized", "captured", "failed", ...
+}
+
+// handlePaymentWebhook は POST /webhooks/payments を処理する。
+// Bearerトークンによるテナント認証は適用されない (呼び出し元はテナントのユーザではなく
+// 決済プロバイダ自身のため) が、代わりに共有シークレットをヘッダで検証する。
+func (s *Server) handlePaymentWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if s.webhookSecret == "" || r.Header.Get("X-Webhook-Secret") != s.webhookSecret {
+		writeError(w, http.StatusUnauthorized, "invalid webhook secret")
+		return
+	}
+
+	var req paymentWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	handle, ok := s.getRide(req.SessionID)
+	if !ok {
+		writeError(w, http.StatusNotFound, "ride not found")
+		return
+	}
+
+	status, err := parsePaymentStatus(req.Status)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	handle.mu.Lock()
+	err = handle.ride.ConfirmCapture(req.ProviderRef, status, time.Now())
+	handle.mu.Unlock()
+	if err != nil {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, struct{}{})
+}
+
+func parsePaymentStatus(s string) (meter.PaymentStatus, error) {
+	switch meter.PaymentStatus(s) {
+	case meter.PaymentStatusAuthorized, meter.PaymentStatusCaptured, meter.PaymentStatusVoided,
+		meter.PaymentStatusRefunded, meter.PaymentStatusFailed:
+		return meter.PaymentStatus(s), nil
+	default:
+		return "", fmt.Errorf("unknown payment status: %q", s)
+	}
+}